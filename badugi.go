@@ -0,0 +1,137 @@
+package goker
+
+import (
+	"slices"
+	"sort"
+)
+
+const badugiHandSize = 4
+
+// BadugiHand represents a 4-card Badugi hand. Only cards of different ranks
+// and different suits play, aces are low, and the lowest hand wins.
+type BadugiHand struct {
+	Cards []Card
+	Best  []Card // Playing cards, sorted from highest to lowest
+}
+
+// NewBadugiHand creates a new BadugiHand from 4 cards and finds its best sub-hand.
+func NewBadugiHand(cards []Card) (*BadugiHand, error) {
+	if len(cards) != badugiHandSize {
+		return nil, ErrInvalidBadugiHandSize
+	}
+
+	if hasDuplicateCards(cards) {
+		return nil, ErrDuplicateCards
+	}
+
+	h := &BadugiHand{Cards: make([]Card, len(cards))}
+	copy(h.Cards, cards)
+	h.Best = findBestBadugi(h.Cards)
+	return h, nil
+}
+
+// findBestBadugi returns the best playable sub-hand, trying the largest
+// sub-hands first since more cards always beat fewer.
+func findBestBadugi(cards []Card) []Card {
+	for n := len(cards); n > 0; n-- {
+		var best []Card
+		for combo := range CombinationsBuffer(cards, n, nil) {
+			if !isBadugi(combo) {
+				continue
+			}
+			candidate := slices.Clone(combo)
+			sortBadugiCards(candidate)
+			if best == nil || compareBadugiCards(candidate, best) > 0 {
+				best = candidate
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// isBadugi checks that no two cards share a rank or a suit.
+func isBadugi(cards []Card) bool {
+	ranks := 0
+	suits := 0
+	for _, c := range cards {
+		if ranks&(1<<int(c.Rank)) != 0 || suits&(1<<int(c.Suit)) != 0 {
+			return false
+		}
+		ranks |= 1 << int(c.Rank)
+		suits |= 1 << int(c.Suit)
+	}
+	return true
+}
+
+// sortBadugiCards sorts cards from highest to lowest low value.
+func sortBadugiCards(cards []Card) {
+	sort.Slice(cards, func(i, j int) bool {
+		return lowValue(cards[i].Rank) > lowValue(cards[j].Rank)
+	})
+}
+
+// compareBadugiCards compares two sorted sub-hands. More cards win, then the
+// lower highest card, then the next highest and so on.
+func compareBadugiCards(a, b []Card) int {
+	if len(a) != len(b) {
+		if len(a) > len(b) {
+			return 1
+		}
+		return -1
+	}
+	for i := range a {
+		av, bv := lowValue(a[i].Rank), lowValue(b[i].Rank)
+		if av < bv {
+			return 1
+		}
+		if av > bv {
+			return -1
+		}
+	}
+	return 0
+}
+
+// Size returns the number of playing cards (1-4).
+func (h *BadugiHand) Size() int {
+	return len(h.Best)
+}
+
+// IsBadugi returns true if all four cards play.
+func (h *BadugiHand) IsBadugi() bool {
+	return len(h.Best) == badugiHandSize
+}
+
+// Compare compares two hands. Returns:
+// -1 if h loses to other
+//
+//	0 if tie
+//	1 if h beats other
+func (h *BadugiHand) Compare(other *BadugiHand) int {
+	return compareBadugiCards(h.Best, other.Best)
+}
+
+// Beats returns true if this hand beats the other.
+func (h *BadugiHand) Beats(other *BadugiHand) bool {
+	return h.Compare(other) > 0
+}
+
+// Ties returns true if this hand ties with the other.
+func (h *BadugiHand) Ties(other *BadugiHand) bool {
+	return h.Compare(other) == 0
+}
+
+// String returns a string representation of the hand's playing cards.
+func (h *BadugiHand) String() string {
+	s := "<BadugiHand: "
+	for i, card := range h.Best {
+		if i > 0 {
+			s += " "
+		}
+		s += card.String()
+	}
+	s += ">"
+	return s
+}
@@ -0,0 +1,90 @@
+package goker
+
+import "testing"
+
+func makeBadugiHand(t *testing.T, cards ...Card) *BadugiHand {
+	t.Helper()
+	h, err := NewBadugiHand(cards)
+	if err != nil {
+		t.Fatalf("Failed to create badugi hand: %v", err)
+	}
+	return h
+}
+
+func TestNewBadugiHandInvalid(t *testing.T) {
+	_, err := NewBadugiHand([]Card{NewCard(Ace, Spades), NewCard(Two, Hearts)})
+	if err != ErrInvalidBadugiHandSize {
+		t.Errorf("NewBadugiHand() error = %v, want ErrInvalidBadugiHandSize", err)
+	}
+
+	_, err = NewBadugiHand([]Card{
+		NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs),
+	})
+	if err != ErrDuplicateCards {
+		t.Errorf("NewBadugiHand() error = %v, want ErrDuplicateCards", err)
+	}
+}
+
+func TestBadugiHandSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []Card
+		size  int
+	}{
+		{"badugi", []Card{NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds)}, 4},
+		{"suited pair", []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Clubs), NewCard(Four, Diamonds)}, 3},
+		{"paired", []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds)}, 3},
+		{"two suits", []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Hearts)}, 2},
+		{"monotone", []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}, 1},
+		{"quads", []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Ace, Diamonds)}, 1},
+	}
+
+	for _, tt := range tests {
+		h := makeBadugiHand(t, tt.cards...)
+		if h.Size() != tt.size {
+			t.Errorf("%s: Size() = %d, want %d (best %v)", tt.name, h.Size(), tt.size, h)
+		}
+		if h.IsBadugi() != (tt.size == 4) {
+			t.Errorf("%s: IsBadugi() = %v", tt.name, h.IsBadugi())
+		}
+	}
+}
+
+func TestBadugiBestSubHand(t *testing.T) {
+	// A♠ 2♠ 3♣ 4♦: the ace plays over the deuce of the same suit
+	h := makeBadugiHand(t, NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Clubs), NewCard(Four, Diamonds))
+	if h.String() != "<BadugiHand: 4♦ 3♣ A♠>" {
+		t.Errorf("Best sub-hand = %v, want <BadugiHand: 4♦ 3♣ A♠>", h)
+	}
+
+	// K♠ 2♠ 3♥ 4♥: the deuce and trey play
+	h = makeBadugiHand(t, NewCard(King, Spades), NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Hearts))
+	if h.String() != "<BadugiHand: 3♥ 2♠>" {
+		t.Errorf("Best sub-hand = %v, want <BadugiHand: 3♥ 2♠>", h)
+	}
+}
+
+func TestBadugiHandCompare(t *testing.T) {
+	wheel := makeBadugiHand(t, NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds))
+	kingBadugi := makeBadugiHand(t, NewCard(King, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds))
+	threeCard := makeBadugiHand(t, NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Clubs), NewCard(Four, Diamonds))
+
+	if !wheel.Beats(kingBadugi) {
+		t.Error("A234 badugi should beat K234 badugi")
+	}
+	if !kingBadugi.Beats(threeCard) {
+		t.Error("Any 4-card badugi should beat a 3-card hand")
+	}
+
+	// 8-high badugis compare from the top card down
+	eightSix := makeBadugiHand(t, NewCard(Eight, Spades), NewCard(Six, Hearts), NewCard(Two, Clubs), NewCard(Ace, Diamonds))
+	eightSeven := makeBadugiHand(t, NewCard(Eight, Hearts), NewCard(Seven, Spades), NewCard(Two, Diamonds), NewCard(Ace, Clubs))
+	if !eightSix.Beats(eightSeven) {
+		t.Error("8-6 badugi should beat 8-7 badugi")
+	}
+
+	sameRanks := makeBadugiHand(t, NewCard(Ace, Hearts), NewCard(Two, Clubs), NewCard(Three, Diamonds), NewCard(Four, Spades))
+	if !wheel.Ties(sameRanks) {
+		t.Error("Badugis with the same ranks should tie")
+	}
+}
@@ -0,0 +1,112 @@
+package goker
+
+import "sort"
+
+// BlockedCombos counts an opponent's weighted combinations before and after
+// removing our hole cards from the deck.
+type BlockedCombos struct {
+	Before float64 // Combinations left once the board is removed
+	After  float64 // Combinations left once our hole cards are removed too
+}
+
+// Blocked returns the combinations our hole cards remove.
+func (b BlockedCombos) Blocked() float64 {
+	return b.Before - b.After
+}
+
+// BlockedFraction returns the share of combinations our hole cards remove.
+func (b BlockedCombos) BlockedFraction() float64 {
+	if b.Before == 0 {
+		return 0
+	}
+	return b.Blocked() / b.Before
+}
+
+// BlockerReport shows how our hole cards change an opponent range's
+// combinations on a board, grouped by what the opponent holds.
+type BlockerReport struct {
+	Total     BlockedCombos
+	Classes   map[MadeHandClass]BlockedCombos // By ClassifyMadeHand class
+	HandRanks [RoyalFlush + 1]BlockedCombos   // By HandRank; index 0 is unused
+
+	// Nuts counts the combinations that make the best hand possible on the
+	// board, as NutHands lists it first.
+	Nuts BlockedCombos
+}
+
+// MostBlocked returns the classes the opponent holds, those whose share we
+// block is largest first.
+func (r *BlockerReport) MostBlocked() []MadeHandClass {
+	classes := make([]MadeHandClass, 0, len(r.Classes))
+	for class := range r.Classes {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		a, b := r.Classes[classes[i]], r.Classes[classes[j]]
+		if a.BlockedFraction() != b.BlockedFraction() {
+			return a.BlockedFraction() > b.BlockedFraction()
+		}
+		return classes[i] > classes[j]
+	})
+	return classes
+}
+
+// AnalyzeBlockers counts an opponent range's combinations on a flop, turn or
+// river by the hand each makes, with and without our hole cards removed from
+// the deck: holding the A♠ on a three-spade board, for instance, blocks every
+// nut flush. It returns ErrEmptyRange if no combination of the range is left
+// once the board is removed.
+func AnalyzeBlockers(holeCards []Card, board *Board, opponent *Range) (*BlockerReport, error) {
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	if err := validateDeal([][]Card{holeCards}, board.Cards, nil); err != nil {
+		return nil, err
+	}
+	if opponent == nil {
+		return nil, ErrEmptyRange
+	}
+
+	boardSet, _ := newCardSet(board.Cards)
+	hole, _ := newCardSet(holeCards)
+	scores, _ := scoreHoleCards(board.Cards, 0)
+	nuts := scores[0]
+
+	report := &BlockerReport{Classes: make(map[MadeHandClass]BlockedCombos)}
+	count := func(c *BlockedCombos, weight float64, blocked bool) {
+		c.Before += weight
+		if !blocked {
+			c.After += weight
+		}
+	}
+
+	cards := append(append([]Card(nil), board.Cards...), Card{}, Card{})
+	for _, h := range opponent.Hands() {
+		weight := opponent.Weight(h)
+		for _, combo := range h.Cards() {
+			set, _ := newCardSet(combo)
+			if set&boardSet != 0 {
+				continue
+			}
+			blocked := set&hole != 0
+
+			copy(cards[len(board.Cards):], combo)
+			best := findBestHand(cards)
+			class := classifyMadeHand(best, combo, board.Cards).Class
+
+			count(&report.Total, weight, blocked)
+			counts := report.Classes[class]
+			count(&counts, weight, blocked)
+			report.Classes[class] = counts
+			count(&report.HandRanks[best.Rank()], weight, blocked)
+			if evaluateCards(cards) == nuts {
+				count(&report.Nuts, weight, blocked)
+			}
+		}
+	}
+
+	if report.Total.Before == 0 {
+		return nil, ErrEmptyRange
+	}
+	return report, nil
+}
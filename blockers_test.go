@@ -0,0 +1,110 @@
+package goker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnalyzeBlockers(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Seven, Spades), NewCard(Four, Diamonds), NewCard(Two, Clubs)}}
+	opponent, err := ParseRange("AJs, KQs, 77, T9s")
+	if err != nil {
+		t.Fatalf("ParseRange error: %v", err)
+	}
+
+	report, err := AnalyzeBlockers([]Card{NewCard(Ace, Spades), NewCard(Five, Hearts)}, board, opponent)
+	if err != nil {
+		t.Fatalf("AnalyzeBlockers error: %v", err)
+	}
+
+	// KsQs and three sevens are on the board; the A♠ removes A♠J♠, the nuts
+	if report.Total.Before != 14 || report.Total.After != 13 {
+		t.Errorf("Total = %+v, want 14 before and 13 after", report.Total)
+	}
+	if report.Nuts.Before != 1 || report.Nuts.After != 0 || report.Nuts.BlockedFraction() != 1 {
+		t.Errorf("Nuts = %+v, want the one nut flush blocked", report.Nuts)
+	}
+	if flushes := report.HandRanks[Flush]; flushes.Before != 2 || flushes.Blocked() != 1 {
+		t.Errorf("HandRanks[Flush] = %+v, want 1 of 2 blocked", flushes)
+	}
+
+	want := map[MadeHandClass]BlockedCombos{
+		NoPair:           {6, 6},
+		TwoPairBothCards: {3, 3},
+		Set:              {3, 3},
+		StraightOrBetter: {2, 1},
+	}
+	if len(report.Classes) != len(want) {
+		t.Errorf("Classes = %v, want %v", report.Classes, want)
+	}
+	for class, combos := range want {
+		if report.Classes[class] != combos {
+			t.Errorf("Classes[%v] = %+v, want %+v", class, report.Classes[class], combos)
+		}
+	}
+	if most := report.MostBlocked(); len(most) != len(want) || most[0] != StraightOrBetter {
+		t.Errorf("MostBlocked = %v, want straights or better first", most)
+	}
+}
+
+func TestAnalyzeBlockersBoardPlays(t *testing.T) {
+	c := NewCard
+	board := &Board{Cards: []Card{c(Nine, Clubs), c(Eight, Diamonds), c(Seven, Hearts), c(Six, Clubs), c(Five, Diamonds)}}
+	opponent, err := ParseRange("JTs, A5s, 22")
+	if err != nil {
+		t.Fatalf("ParseRange error: %v", err)
+	}
+
+	report, err := AnalyzeBlockers([]Card{c(Ten, Spades), c(Four, Hearts)}, board, opponent)
+	if err != nil {
+		t.Fatalf("AnalyzeBlockers error: %v", err)
+	}
+
+	// Only JTs beats the board's straight; A5s and 22 play the board even
+	// though the fives hold a card of it
+	want := map[MadeHandClass]BlockedCombos{
+		PlayingTheBoard:  {9, 9},
+		StraightOrBetter: {4, 3},
+	}
+	if len(report.Classes) != len(want) {
+		t.Errorf("Classes = %v, want %v", report.Classes, want)
+	}
+	for class, combos := range want {
+		if report.Classes[class] != combos {
+			t.Errorf("Classes[%v] = %+v, want %+v", class, report.Classes[class], combos)
+		}
+	}
+	if straights := report.HandRanks[Straight]; straights.Before != 13 {
+		t.Errorf("HandRanks[Straight] = %+v, want all 13 combos", straights)
+	}
+}
+
+func TestAnalyzeBlockersWeights(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Seven, Spades)}}
+	opponent := &Range{}
+	opponent.Set(StartingHand{High: Ace, Low: Ace}, 0.5)
+
+	report, err := AnalyzeBlockers([]Card{NewCard(Ace, Spades), NewCard(Five, Hearts)}, board, opponent)
+	if err != nil {
+		t.Fatalf("AnalyzeBlockers error: %v", err)
+	}
+	if report.Total.Before != 3 || report.Total.After != 1.5 {
+		t.Errorf("Total = %+v, want 3 before and 1.5 after", report.Total)
+	}
+}
+
+func TestAnalyzeBlockersInvalid(t *testing.T) {
+	hole := []Card{NewCard(Ace, Spades), NewCard(King, Spades)}
+	board := &Board{Cards: []Card{NewCard(Two, Clubs), NewCard(Two, Diamonds), NewCard(Two, Hearts)}}
+
+	if _, err := AnalyzeBlockers(hole, board, nil); !errors.Is(err, ErrEmptyRange) {
+		t.Errorf("AnalyzeBlockers with no range error = %v, want ErrEmptyRange", err)
+	}
+	deuces, _ := ParseRange("22")
+	if _, err := AnalyzeBlockers(hole, board, deuces); !errors.Is(err, ErrEmptyRange) {
+		t.Errorf("AnalyzeBlockers with every combo on the board error = %v, want ErrEmptyRange", err)
+	}
+	if _, err := AnalyzeBlockers(hole, NewBoard(), deuces); !errors.Is(err, ErrInvalidBoardSize) {
+		t.Errorf("AnalyzeBlockers preflop error = %v, want ErrInvalidBoardSize", err)
+	}
+}
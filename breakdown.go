@@ -0,0 +1,172 @@
+package goker
+
+// EquityBreakdown describes how a player's equity is made up: the hands they
+// finish with and how often they are ahead as each street is dealt. It is
+// computed when EquityOptions.Breakdown is set.
+type EquityBreakdown struct {
+	// HandRanks[r] counts the boards on which the player's final hand has
+	// rank r, and how many of those it wins or ties. Index 0 is unused.
+	HandRanks [RoyalFlush + 1]HandRankOutcome
+
+	// Streets holds the player's results if the hand were shown down after
+	// each street still to come, ending with the river.
+	Streets []StreetEquity
+
+	Total int // Boards counted, as in EquityResult.Total
+}
+
+// HandRankOutcome counts the boards on which a player makes a hand rank.
+type HandRankOutcome struct {
+	Count int // Boards on which the player's final hand has the rank
+	Wins  int // Of those, boards the player wins outright
+	Ties  int // Of those, boards the player ties
+}
+
+// StreetEquity is a player's share of the pot if the hand were shown down
+// with the board at a given size. Equity averaged over every way a street
+// can come is the same as before it, so what changes from street to street
+// is how often the player is ahead.
+type StreetEquity struct {
+	BoardCards int // 3 for the flop, 4 for the turn and 5 for the river
+	Wins       int
+	Ties       int
+	Total      int
+	Equity     float64 // (wins + ties/numPlayers) / total
+}
+
+// Frequency returns the share of boards on which the player's final hand
+// has the rank.
+func (b *EquityBreakdown) Frequency(rank HandRank) float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return float64(b.HandRanks[rank].Count) / float64(b.Total)
+}
+
+// WinRate returns the share of boards the player wins outright when their
+// final hand has the rank.
+func (b *EquityBreakdown) WinRate(rank HandRank) float64 {
+	o := b.HandRanks[rank]
+	if o.Count == 0 {
+		return 0
+	}
+	return float64(o.Wins) / float64(o.Count)
+}
+
+// TieRate returns the share of boards the player ties when their final hand
+// has the rank.
+func (b *EquityBreakdown) TieRate(rank HandRank) float64 {
+	o := b.HandRanks[rank]
+	if o.Count == 0 {
+		return 0
+	}
+	return float64(o.Ties) / float64(o.Count)
+}
+
+// Street returns the player's results with the board at a size, and false
+// if that street isn't still to come.
+func (b *EquityBreakdown) Street(boardCards int) (StreetEquity, bool) {
+	for _, s := range b.Streets {
+		if s.BoardCards == boardCards {
+			return s, true
+		}
+	}
+	return StreetEquity{}, false
+}
+
+// breakdownTally counts the outcomes behind an EquityBreakdown for every
+// player.
+type breakdownTally struct {
+	ranks   [][RoyalFlush + 1]HandRankOutcome
+	streets [][handSize + 1]StreetEquity // Indexed by board size
+}
+
+func newBreakdownTally(numPlayers int) *breakdownTally {
+	return &breakdownTally{
+		ranks:   make([][RoyalFlush + 1]HandRankOutcome, numPlayers),
+		streets: make([][handSize + 1]StreetEquity, numPlayers),
+	}
+}
+
+// add merges another tally into this one.
+func (t *breakdownTally) add(other *breakdownTally) {
+	for i := range t.ranks {
+		for r := range t.ranks[i] {
+			t.ranks[i][r].Count += other.ranks[i][r].Count
+			t.ranks[i][r].Wins += other.ranks[i][r].Wins
+			t.ranks[i][r].Ties += other.ranks[i][r].Ties
+		}
+		for k := range t.streets[i] {
+			t.streets[i][k].Wins += other.streets[i][k].Wins
+			t.streets[i][k].Ties += other.streets[i][k].Ties
+			t.streets[i][k].Total += other.streets[i][k].Total
+		}
+	}
+}
+
+// breakdown returns a player's breakdown, taking the river from their result.
+func (t *breakdownTally) breakdown(player int, result EquityResult) *EquityBreakdown {
+	b := &EquityBreakdown{HandRanks: t.ranks[player], Total: result.Total}
+	numPlayers := len(t.ranks)
+	streets := t.streets[player]
+	streets[handSize] = StreetEquity{Wins: result.Wins, Ties: result.Ties, Total: result.Total}
+	for k, s := range streets {
+		if s.Total == 0 {
+			continue
+		}
+		s.BoardCards = k
+		s.Equity = (float64(s.Wins) + float64(s.Ties)/float64(numPlayers)) / float64(s.Total)
+		b.Streets = append(b.Streets, s)
+	}
+	return b
+}
+
+// streetDeals returns, for each street still to come before the river, the
+// positions within a runout of the cards that could make up that street's
+// new cards. Sampled runouts are dealt in random order, so their first cards
+// are a random deal of the street; an exact runout is an unordered set of
+// cards, so every subset of it has to be counted.
+func streetDeals(boardLen int, exact bool) map[int][][]int {
+	positions := make([]int, handSize-boardLen)
+	for i := range positions {
+		positions[i] = i
+	}
+
+	deals := make(map[int][][]int)
+	for k := max(3, boardLen+1); k < handSize; k++ {
+		dealt := k - boardLen
+		if !exact {
+			deals[k] = [][]int{positions[:dealt]}
+			continue
+		}
+		deals[k] = Combinations(positions, dealt)
+	}
+	return deals
+}
+
+// recordStreets adds the outcome of showing down after each street to come
+// before the river, counting each way the street can come from the runout
+// weight times.
+func (s *showdown) recordStreets(board []Card, weight int, tally *equityTally) {
+	for k, deals := range s.streetDeals {
+		for _, deal := range deals {
+			s.street = append(s.street[:0], board[:s.boardLen]...)
+			for _, p := range deal {
+				s.street = append(s.street, board[s.boardLen+p])
+			}
+
+			best, winners := s.score(s.street)
+			streets := tally.breakdown.streets
+			for i, score := range s.scores {
+				switch {
+				case score != best:
+				case winners == 1:
+					streets[i][k].Wins += weight
+				default:
+					streets[i][k].Ties += weight
+				}
+				streets[i][k].Total += weight
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package goker
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestEquityBreakdownExact(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+		{NewCard(Queen, Spades), NewCard(Queen, Clubs)},
+	}
+	board := []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs)}
+
+	results, err := ec.CalculateExactWithOptions(context.Background(), holeCards, board, EquityOptions{Breakdown: true})
+	if err != nil {
+		t.Fatalf("CalculateExactWithOptions error: %v", err)
+	}
+
+	for i, r := range results {
+		b := r.Breakdown
+		if b == nil {
+			t.Fatalf("player %d has no breakdown", i)
+		}
+		count, wins, ties := 0, 0, 0
+		for _, o := range b.HandRanks {
+			count += o.Count
+			wins += o.Wins
+			ties += o.Ties
+		}
+		if count != r.Total || wins != r.Wins || ties != r.Ties {
+			t.Errorf("player %d hand ranks count %d boards, %d wins, %d ties, want %d, %d, %d",
+				i, count, wins, ties, r.Total, r.Wins, r.Ties)
+		}
+
+		if len(b.Streets) != 2 || b.Streets[0].BoardCards != 4 || b.Streets[1].BoardCards != 5 {
+			t.Fatalf("player %d streets = %+v, want the turn and river", i, b.Streets)
+		}
+		if river, _ := b.Street(5); river.Equity != r.Equity {
+			t.Errorf("player %d river equity = %v, want %v", i, river.Equity, r.Equity)
+		}
+	}
+
+	// 9 hearts are left: every runout but the 36*35/2 without one flushes,
+	// which wins unless the board pairs for a full house
+	if got := results[0].Breakdown.HandRanks[Flush].Count; got != 990-630 {
+		t.Errorf("AK flushes on %d boards, want %d", got, 990-630)
+	}
+	if rate := results[0].Breakdown.WinRate(Flush); rate < 0.95 || rate == 1 {
+		t.Errorf("AK win rate with a flush = %v, want just under 1", rate)
+	}
+
+	// Showing down on the turn: AK is ahead only when it pairs or flushes
+	wantTurn := 0
+	for _, c := range buildRemainingDeck(usedCards(holeCards, board, nil)) {
+		turn := append(append([]Card(nil), board...), c)
+		a := evaluateCards(append(append([]Card(nil), holeCards[0]...), turn...))
+		b := evaluateCards(append(append([]Card(nil), holeCards[1]...), turn...))
+		if a > b {
+			wantTurn++
+		}
+	}
+	turn, ok := results[0].Breakdown.Street(4)
+	if !ok {
+		t.Fatal("no turn results")
+	}
+	if want := float64(wantTurn) / 45; math.Abs(turn.Equity-want) > 1e-9 {
+		t.Errorf("AK turn equity = %.4f, want %.4f", turn.Equity, want)
+	}
+	if _, ok := results[0].Breakdown.Street(3); ok {
+		t.Error("got flop results for a flop spot")
+	}
+}
+
+func TestEquityBreakdownSampled(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(Seven, Clubs), NewCard(Two, Diamonds)},
+	}
+
+	results, err := ec.CalculateWithOptions(context.Background(), holeCards, nil, EquityOptions{Simulations: 20000, Breakdown: true})
+	if err != nil {
+		t.Fatalf("CalculateWithOptions error: %v", err)
+	}
+
+	b := results[0].Breakdown
+	if b == nil || b.Total != 20000 {
+		t.Fatalf("breakdown = %+v, want one over 20000 simulations", b)
+	}
+	if len(b.Streets) != 3 {
+		t.Fatalf("streets = %+v, want the flop, turn and river", b.Streets)
+	}
+	for _, s := range b.Streets {
+		if s.Total != 20000 || s.Equity < 0.8 {
+			t.Errorf("AA on a board of %d cards = %+v, want ahead most of the time", s.BoardCards, s)
+		}
+	}
+
+	// AA always has at least a pair, and makes two pair about 40% of the time
+	if f := b.Frequency(HighCard); f != 0 {
+		t.Errorf("AA high card frequency = %v, want 0", f)
+	}
+	if f := b.Frequency(TwoPair); math.Abs(f-0.40) > 0.03 {
+		t.Errorf("AA two pair frequency = %.3f, want about 0.40", f)
+	}
+	if results[1].Breakdown.TieRate(Straight) == 0 && results[1].Breakdown.WinRate(Straight) == 0 {
+		t.Error("72o never won or tied with a straight")
+	}
+}
+
+func TestEquityNoBreakdown(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(Seven, Clubs), NewCard(Two, Diamonds)},
+	}
+	board := []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs)}
+
+	results, err := ec.Calculate(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
+	if results[0].Breakdown != nil {
+		t.Error("got a breakdown without asking for one")
+	}
+}
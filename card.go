@@ -63,6 +63,14 @@ func (r CardRank) String() string {
 	}
 }
 
+// lowValue returns a rank's value in low games, where Ace counts as 1.
+func lowValue(r CardRank) int {
+	if r == Ace {
+		return 1
+	}
+	return int(r)
+}
+
 // Card represents a single playing card with a rank and suit.
 type Card struct {
 	Rank CardRank
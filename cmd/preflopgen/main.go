@@ -0,0 +1,46 @@
+// Command preflopgen generates the preflop equity table embedded in goker.
+//
+// Usage:
+//
+//	go run ./cmd/preflopgen -o preflop_equity.bin [-random-samples n]
+//
+// Heads-up results are exact, enumerating every board for every matchup of
+// hole cards. Equities against 2 or more random hands are Monte Carlo
+// estimates from -random-samples deals per starting hand and player count,
+// so that part of the table is approximate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MaxAtkinson/goker"
+)
+
+func main() {
+	output := flag.String("o", "preflop_equity.bin", "output file")
+	randomSamples := flag.Int("random-samples", 100000, "random deals per starting hand and player count for the sampled equities against 2 or more random hands")
+	workers := flag.Int("workers", 0, "worker goroutines (0 uses every CPU)")
+	seed := flag.Int64("seed", 1, "random seed")
+	flag.Parse()
+
+	start := time.Now()
+	table := goker.GeneratePreflopTable(goker.PreflopTableOptions{
+		RandomSamples: *randomSamples,
+		Workers:       *workers,
+		Seed:          *seed,
+	})
+
+	data, err := table.MarshalBinary()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote %s (%d bytes) in %v\n", *output, len(data), time.Since(start).Round(time.Second))
+}
@@ -11,6 +11,21 @@ type Deck struct {
 
 // NewDeck creates a new shuffled 52-card deck.
 func NewDeck() *Deck {
+	d := newOrderedDeck()
+	d.Shuffle()
+	return d
+}
+
+// NewSeededDeck creates a new 52-card deck shuffled with the given random
+// source, so the same seed always produces the same deal.
+func NewSeededDeck(rng *rand.Rand) *Deck {
+	d := newOrderedDeck()
+	d.ShuffleWith(rng)
+	return d
+}
+
+// newOrderedDeck creates an unshuffled 52-card deck.
+func newOrderedDeck() *Deck {
 	d := &Deck{
 		cards: make([]Card, 0, 52),
 	}
@@ -19,6 +34,16 @@ func NewDeck() *Deck {
 			d.cards = append(d.cards, NewCard(rank, suit))
 		}
 	}
+	return d
+}
+
+// NewDeckFromCards creates a new shuffled deck containing the given cards.
+// Useful for variants that don't use the standard 52-card deck.
+func NewDeckFromCards(cards []Card) *Deck {
+	d := &Deck{
+		cards: make([]Card, len(cards)),
+	}
+	copy(d.cards, cards)
 	d.Shuffle()
 	return d
 }
@@ -30,6 +55,14 @@ func (d *Deck) Shuffle() {
 	})
 }
 
+// ShuffleWith randomizes the order of cards in the deck using the given
+// random source, for reproducible deals.
+func (d *Deck) ShuffleWith(rng *rand.Rand) {
+	rng.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+}
+
 // Len returns the number of cards remaining in the deck.
 func (d *Deck) Len() int {
 	return len(d.cards)
@@ -1,6 +1,9 @@
 package goker
 
-import "testing"
+import (
+	"math/rand"
+	"testing"
+)
 
 func TestNewDeck(t *testing.T) {
 	deck := NewDeck()
@@ -108,3 +111,41 @@ func TestDeckShuffle(t *testing.T) {
 		t.Error("Two shuffled decks should not be identical")
 	}
 }
+
+func TestNewDeckFromCards(t *testing.T) {
+	cards := []Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades)}
+	deck := NewDeckFromCards(cards)
+
+	if deck.Len() != 3 {
+		t.Errorf("NewDeckFromCards() has %d cards, want 3", deck.Len())
+	}
+
+	// Drawing must not modify the caller's slice
+	deck.Draw()
+	if cards[0] != NewCard(Ace, Spades) || len(cards) != 3 {
+		t.Error("NewDeckFromCards() should copy the given cards")
+	}
+}
+
+func TestNewSeededDeck(t *testing.T) {
+	d1 := NewSeededDeck(rand.New(rand.NewSource(42)))
+	d2 := NewSeededDeck(rand.New(rand.NewSource(42)))
+
+	if d1.Len() != 52 {
+		t.Errorf("NewSeededDeck() has %d cards, want 52", d1.Len())
+	}
+	for i := range d1.cards {
+		if d1.cards[i] != d2.cards[i] {
+			t.Fatal("NewSeededDeck() with the same seed should produce the same order")
+		}
+	}
+
+	// Reshuffling with the same seed keeps the decks in step
+	d1.ShuffleWith(rand.New(rand.NewSource(7)))
+	d2.ShuffleWith(rand.New(rand.NewSource(7)))
+	for i := range d1.cards {
+		if d1.cards[i] != d2.cards[i] {
+			t.Fatal("ShuffleWith() with the same seed should produce the same order")
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package goker
+
+import "math/bits"
+
+// DrawKind is a kind of drawing hand.
+type DrawKind int
+
+const (
+	FlushDraw             DrawKind = iota + 1 // Four to a flush
+	OpenEndedStraightDraw                     // Four in a row, open at both ends
+	DoubleGutshot                             // Two ranks complete different straights
+	Gutshot                                   // One rank completes a straight
+	BackdoorFlushDraw                         // Three to a flush on the flop
+	BackdoorStraightDraw                      // Two running cards complete a straight on the flop
+	Overcards                                 // Both hole cards above the board, with no pair
+)
+
+func (k DrawKind) String() string {
+	switch k {
+	case FlushDraw:
+		return "Flush Draw"
+	case OpenEndedStraightDraw:
+		return "Open-Ended Straight Draw"
+	case DoubleGutshot:
+		return "Double Gutshot"
+	case Gutshot:
+		return "Gutshot"
+	case BackdoorFlushDraw:
+		return "Backdoor Flush Draw"
+	case BackdoorStraightDraw:
+		return "Backdoor Straight Draw"
+	case Overcards:
+		return "Overcards"
+	default:
+		return "Unknown"
+	}
+}
+
+// Draw is one draw a player holds.
+type Draw struct {
+	Kind DrawKind
+
+	// Outs are the unseen cards that complete the draw on the next card, or
+	// pair a hole card for Overcards. Backdoor draws need two cards and have
+	// none.
+	Outs []Card
+
+	// Nut is set on flush draws, backdoor or not, that make the best
+	// possible flush when they come in.
+	Nut bool
+}
+
+// DrawInfo describes the draws a player holds on a flop or turn.
+type DrawInfo struct {
+	Draws []Draw
+	Outs  []Card // Every card that completes a draw next, each counted once
+
+	// Combo is set when the player holds both a flush draw and a straight
+	// draw.
+	Combo bool
+}
+
+// Has reports whether the player holds a draw of the kind.
+func (d *DrawInfo) Has(kind DrawKind) bool {
+	_, ok := d.Draw(kind)
+	return ok
+}
+
+// Draw returns the player's draw of the kind, and false if they don't hold
+// one.
+func (d *DrawInfo) Draw(kind DrawKind) (Draw, bool) {
+	for _, draw := range d.Draws {
+		if draw.Kind == kind {
+			return draw, true
+		}
+	}
+	return Draw{}, false
+}
+
+// AnalyzeDraws finds the draws a player's hole cards make with a flop or
+// turn. Only draws that use a hole card count, and a player who already has
+// a flush or straight isn't drawing to one. Straight draws are reported as
+// the single strongest kind that applies.
+func AnalyzeDraws(holeCards []Card, board *Board) (*DrawInfo, error) {
+	if board == nil {
+		return nil, ErrInvalidBoardSize
+	}
+	if err := validateDeal([][]Card{holeCards}, board.Cards, nil); err != nil {
+		return nil, err
+	}
+	if n := len(board.Cards); n != 3 && n != 4 {
+		return nil, ErrInvalidBoardSize
+	}
+
+	hole, _ := newCardSet(holeCards)
+	boardSet, _ := newCardSet(board.Cards)
+	all := hole | boardSet
+	unseen := fullCardSet &^ all
+
+	info := &DrawInfo{}
+	var outs cardSet
+	add := func(kind DrawKind, cards cardSet, nut bool) {
+		info.Draws = append(info.Draws, Draw{Kind: kind, Outs: sortedByRank(cards.cards()), Nut: nut})
+		outs |= cards
+	}
+
+	flushDraw := false
+	for suit := Clubs; suit <= Spades; suit++ {
+		shift := 13 * int(suit)
+		if hole>>shift&rankBits == 0 {
+			continue
+		}
+		switch count := popCount(all >> shift & rankBits); {
+		case count == 4:
+			add(FlushDraw, unseen&(rankBits<<shift), nutFlushDraw(holeCards, board.Cards, suit))
+			flushDraw = true
+		case count == 3 && len(board.Cards) == 3:
+			add(BackdoorFlushDraw, 0, nutFlushDraw(holeCards, board.Cards, suit))
+		}
+	}
+
+	ranks, boardRanks := rankMask(holeCards)|rankMask(board.Cards), rankMask(board.Cards)
+	straightDraw := false
+	if straightHigh(ranks) == 0 {
+		// Ranks that complete a straight the board alone wouldn't make
+		var completing int
+		for r := Two; r <= Ace; r++ {
+			bit := 1 << r
+			if ranks&bit == 0 && straightHigh(ranks|bit) > straightHigh(boardRanks|bit) {
+				completing |= bit
+			}
+		}
+
+		var straightOuts cardSet
+		for suit := Clubs; suit <= Spades; suit++ {
+			straightOuts |= cardSet(completing>>Two) << (13 * int(suit))
+		}
+		switch {
+		case completing == 0:
+		case openEnded(ranks, completing):
+			add(OpenEndedStraightDraw, straightOuts, false)
+		case popCount(cardSet(completing)) > 1:
+			add(DoubleGutshot, straightOuts, false)
+		default:
+			add(Gutshot, straightOuts, false)
+		}
+		straightDraw = completing != 0
+
+		if !straightDraw && len(board.Cards) == 3 && backdoorStraight(ranks, boardRanks) {
+			add(BackdoorStraightDraw, 0, false)
+		}
+	}
+
+	lowHole := min(holeCards[0].Rank, holeCards[1].Rank)
+	if lowHole > topRanks(boardRanks, 1)[0] && evaluateCards(append(append([]Card(nil), holeCards...), board.Cards...)).Rank() == HighCard {
+		var overOuts cardSet
+		for _, c := range holeCards {
+			for suit := Clubs; suit <= Spades; suit++ {
+				overOuts |= cardBit(NewCard(c.Rank, suit))
+			}
+		}
+		add(Overcards, overOuts&unseen, false)
+	}
+
+	info.Outs = sortedByRank(outs.cards())
+	info.Combo = flushDraw && straightDraw
+	return info, nil
+}
+
+// rankMask returns the set of ranks among cards, bit r set for rank r, as
+// Hand and the evaluator use.
+func rankMask(cards []Card) int {
+	ranks := 0
+	for _, c := range cards {
+		ranks |= 1 << c.Rank
+	}
+	return ranks
+}
+
+// popCount returns the number of cards in a set.
+func popCount(s cardSet) int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// nutFlushDraw reports whether the player's hole cards in a suit include the
+// highest card of the suit not on the board.
+func nutFlushDraw(holeCards, board []Card, suit CardSuit) bool {
+	onBoard := 0
+	for _, c := range board {
+		if c.Suit == suit {
+			onBoard |= 1 << c.Rank
+		}
+	}
+	nut := Ace
+	for onBoard&(1<<nut) != 0 {
+		nut--
+	}
+	for _, c := range holeCards {
+		if c.Suit == suit && c.Rank == nut {
+			return true
+		}
+	}
+	return false
+}
+
+// openEnded reports whether four ranks in a row are held with the rank on
+// each side completing a straight, counting the ace as low below a deuce.
+func openEnded(ranks, completing int) bool {
+	withLowAce := func(mask int) int {
+		return mask | mask>>Ace&1<<1
+	}
+	ranks, completing = withLowAce(ranks), withLowAce(completing)
+	for low := 2; low+4 <= int(Ace); low++ {
+		run := 0xf << low
+		if ranks&run == run && completing&(1<<(low-1)) != 0 && completing&(1<<(low+4)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// backdoorStraight reports whether two more ranks complete a straight the
+// board wouldn't make with them alone.
+func backdoorStraight(ranks, boardRanks int) bool {
+	for a := Two; a <= Ace; a++ {
+		for b := a + 1; b <= Ace; b++ {
+			pair := 1<<a | 1<<b
+			if ranks&pair == 0 && straightHigh(ranks|pair) > straightHigh(boardRanks|pair) {
+				return true
+			}
+		}
+	}
+	return false
+}
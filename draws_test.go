@@ -0,0 +1,162 @@
+package goker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnalyzeDraws(t *testing.T) {
+	tests := []struct {
+		name  string
+		hole  []Card
+		board []Card
+		draws map[DrawKind]int // Outs of each draw expected
+		nut   bool             // Whether the flush draw is to the nuts
+		combo bool
+		outs  int
+	}{
+		{
+			"nut flush draw and overcards",
+			[]Card{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+			[]Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs)},
+			map[DrawKind]int{FlushDraw: 9, Overcards: 6},
+			true, false, 15,
+		},
+		{
+			"open-ended",
+			[]Card{NewCard(Eight, Spades), NewCard(Nine, Diamonds)},
+			[]Card{NewCard(Seven, Clubs), NewCard(Six, Hearts), NewCard(King, Spades)},
+			map[DrawKind]int{OpenEndedStraightDraw: 8},
+			false, false, 8,
+		},
+		{
+			"wheel open-ended",
+			[]Card{NewCard(Five, Clubs), NewCard(Four, Diamonds)},
+			[]Card{NewCard(Three, Hearts), NewCard(Two, Spades), NewCard(King, Diamonds)},
+			map[DrawKind]int{OpenEndedStraightDraw: 8},
+			false, false, 8,
+		},
+		{
+			"gutshot",
+			[]Card{NewCard(Nine, Spades), NewCard(Eight, Diamonds)},
+			[]Card{NewCard(Six, Clubs), NewCard(Five, Hearts), NewCard(King, Diamonds)},
+			map[DrawKind]int{Gutshot: 4},
+			false, false, 4,
+		},
+		{
+			"double gutshot",
+			[]Card{NewCard(Nine, Clubs), NewCard(Seven, Diamonds)},
+			[]Card{NewCard(Jack, Hearts), NewCard(Eight, Spades), NewCard(Five, Clubs)},
+			map[DrawKind]int{DoubleGutshot: 8},
+			false, false, 8,
+		},
+		{
+			"combo draw",
+			[]Card{NewCard(Jack, Hearts), NewCard(Ten, Hearts)},
+			[]Card{NewCard(Nine, Hearts), NewCard(Eight, Hearts), NewCard(King, Clubs)},
+			map[DrawKind]int{FlushDraw: 9, OpenEndedStraightDraw: 8},
+			false, true, 15,
+		},
+		{
+			"backdoors",
+			[]Card{NewCard(Ace, Hearts), NewCard(Queen, Hearts)},
+			[]Card{NewCard(King, Hearts), NewCard(Seven, Clubs), NewCard(Two, Diamonds)},
+			map[DrawKind]int{BackdoorFlushDraw: 0, BackdoorStraightDraw: 0},
+			true, false, 0,
+		},
+		{
+			"made straight",
+			[]Card{NewCard(Eight, Spades), NewCard(Nine, Diamonds)},
+			[]Card{NewCard(Seven, Clubs), NewCard(Six, Hearts), NewCard(Five, Spades)},
+			map[DrawKind]int{},
+			false, false, 0,
+		},
+		{
+			"board straight draw",
+			[]Card{NewCard(Two, Spades), NewCard(Two, Diamonds)},
+			[]Card{NewCard(Nine, Clubs), NewCard(Eight, Hearts), NewCard(Seven, Spades), NewCard(Six, Diamonds)},
+			map[DrawKind]int{},
+			false, false, 0,
+		},
+		{
+			"turn has no backdoors",
+			[]Card{NewCard(Ace, Hearts), NewCard(Queen, Hearts)},
+			[]Card{NewCard(King, Hearts), NewCard(Seven, Clubs), NewCard(Two, Diamonds), NewCard(Three, Spades)},
+			map[DrawKind]int{},
+			false, false, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := AnalyzeDraws(tt.hole, &Board{Cards: tt.board})
+			if err != nil {
+				t.Fatalf("AnalyzeDraws error: %v", err)
+			}
+			if len(info.Draws) != len(tt.draws) {
+				t.Errorf("AnalyzeDraws = %+v, want %v", info.Draws, tt.draws)
+			}
+			for kind, outs := range tt.draws {
+				draw, ok := info.Draw(kind)
+				if !ok {
+					t.Errorf("missing %v", kind)
+					continue
+				}
+				if len(draw.Outs) != outs {
+					t.Errorf("%v has %d outs, want %d", kind, len(draw.Outs), outs)
+				}
+				if (kind == FlushDraw || kind == BackdoorFlushDraw) && draw.Nut != tt.nut {
+					t.Errorf("%v Nut = %v, want %v", kind, draw.Nut, tt.nut)
+				}
+			}
+			if info.Combo != tt.combo {
+				t.Errorf("Combo = %v, want %v", info.Combo, tt.combo)
+			}
+			if len(info.Outs) != tt.outs {
+				t.Errorf("AnalyzeDraws has %d outs, want %d", len(info.Outs), tt.outs)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDrawsOuts(t *testing.T) {
+	info, err := AnalyzeDraws(
+		[]Card{NewCard(Eight, Spades), NewCard(Nine, Diamonds)},
+		&Board{Cards: []Card{NewCard(Seven, Clubs), NewCard(Six, Hearts), NewCard(King, Spades)}},
+	)
+	if err != nil {
+		t.Fatalf("AnalyzeDraws error: %v", err)
+	}
+	for _, c := range info.Outs {
+		if c.Rank != Five && c.Rank != Ten {
+			t.Errorf("out %v doesn't complete 6-7-8-9", c)
+		}
+	}
+	if !info.Has(OpenEndedStraightDraw) || info.Has(Gutshot) {
+		t.Errorf("Has reports %+v", info.Draws)
+	}
+}
+
+func TestAnalyzeDrawsInvalid(t *testing.T) {
+	hole := []Card{NewCard(Ace, Spades), NewCard(King, Spades)}
+	flop := []Card{NewCard(Two, Clubs), NewCard(Three, Clubs), NewCard(Four, Clubs)}
+
+	tests := []struct {
+		name     string
+		hole     []Card
+		board    *Board
+		expected error
+	}{
+		{"preflop", hole, NewBoard(), ErrInvalidBoardSize},
+		{"river", hole, &Board{Cards: append(flop[:3:3], NewCard(Five, Hearts), NewCard(Six, Hearts))}, ErrInvalidBoardSize},
+		{"no board", hole, nil, ErrInvalidBoardSize},
+		{"three hole cards", append(hole[:2:2], NewCard(Queen, Spades)), &Board{Cards: flop}, ErrInvalidHoleCards},
+		{"duplicate", hole, &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(Three, Clubs), NewCard(Four, Clubs)}}, ErrDuplicateCards},
+	}
+
+	for _, tt := range tests {
+		if _, err := AnalyzeDraws(tt.hole, tt.board); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: AnalyzeDraws error = %v, want %v", tt.name, err, tt.expected)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package goker
 
 import (
+	"context"
+	"iter"
+	"math"
+	"math/bits"
 	"math/rand"
 	"runtime"
 	"sync"
-	"sync/atomic"
+	"time"
 )
 
 // EquityResult holds the equity calculation results for a player.
@@ -14,6 +18,25 @@ type EquityResult struct {
 	Losses int     // Number of losses
 	Total  int     // Total simulations
 	Equity float64 // Win probability (wins + ties/numPlayers) / total
+
+	// StdErr is the standard error of Equity for sampled results, and 0 for
+	// exact ones.
+	StdErr float64
+
+	// Breakdown is set when asked for with EquityOptions.Breakdown.
+	Breakdown *EquityBreakdown
+
+	// Partial is set when a run was stopped before completing, in which case
+	// Total counts only the simulations or boards that were evaluated.
+	Partial bool
+}
+
+// ConfidenceInterval returns the interval around Equity that contains the
+// true equity with the given confidence (e.g. 0.95), from the standard
+// error and clamped to [0, 1].
+func (r EquityResult) ConfidenceInterval(confidence float64) (low, high float64) {
+	margin := math.Sqrt2 * math.Erfinv(confidence) * r.StdErr
+	return max(0, r.Equity-margin), min(1, r.Equity+margin)
 }
 
 // EquityCalculator calculates hand equity through Monte Carlo simulation.
@@ -30,206 +53,577 @@ func NewEquityCalculator(workers int) *EquityCalculator {
 	return &EquityCalculator{workers: workers}
 }
 
+// EquityProgress reports how far an equity run has got.
+type EquityProgress struct {
+	Completed int            // Simulations or boards evaluated so far
+	Total     int            // Simulations or boards the run will evaluate (an estimate for CalculatePrecise)
+	Results   []EquityResult // Estimates from the completed work
+}
+
+// Batch sizes for splitting equity runs between workers; small enough that
+// cancellation is noticed promptly.
+const (
+	simulationBatchSize = 1000
+	runoutBatchSize     = 500
+)
+
+// EquityOptions configures an equity run.
+type EquityOptions struct {
+	// Dead holds cards known to be out of the deck, such as cards folded
+	// face up or exposed, which can't appear on the board.
+	Dead []Card
+
+	// Rand is the source of random runouts. Runs with the same source and
+	// seed give the same results whatever the number of workers. If nil,
+	// the global source is used.
+	Rand *rand.Rand
+
+	// Simulations is the number of random runouts to sample.
+	Simulations int
+
+	// MaxCombinations is the most boards exact enumeration will run
+	// through; zero means no limit.
+	MaxCombinations int
+
+	// Breakdown adds an EquityBreakdown of each player's final hands and
+	// results street by street to their result, at some extra cost.
+	Breakdown bool
+
+	Workers  int                  // If <= 0, uses the calculator's workers
+	Progress func(EquityProgress) // If set, called as work completes
+}
+
+// workersFor returns the number of workers a run with the options uses.
+func (ec *EquityCalculator) workersFor(opts EquityOptions) int {
+	if opts.Workers > 0 {
+		return opts.Workers
+	}
+	return ec.workers
+}
+
 // Calculate runs Monte Carlo simulation to determine equity for each player's hole cards.
 // holeCards: slice of 2-card arrays for each player
-// board: current community cards (0-5 cards)
+// board: current community cards (0, 3, 4 or 5 cards)
 // simulations: number of random board runouts to simulate
-func (ec *EquityCalculator) Calculate(holeCards [][]Card, board []Card, simulations int) []EquityResult {
-	numPlayers := len(holeCards)
-	results := make([]EquityResult, numPlayers)
+//
+// Invalid spots return an error, see CalculateWithOptions.
+func (ec *EquityCalculator) Calculate(holeCards [][]Card, board []Card, simulations int) ([]EquityResult, error) {
+	return ec.CalculateContext(context.Background(), holeCards, board, simulations, nil)
+}
 
-	// Track wins/ties atomically
-	wins := make([]int64, numPlayers)
-	ties := make([]int64, numPlayers)
+// CalculateContext is Calculate with cancellation and progress reporting.
+// If ctx ends first it stops promptly and returns the results of the
+// simulations run so far, marked Partial, with ctx's error. If progress is
+// not nil it is called from the calling goroutine as simulations complete.
+func (ec *EquityCalculator) CalculateContext(ctx context.Context, holeCards [][]Card, board []Card, simulations int, progress func(EquityProgress)) ([]EquityResult, error) {
+	return ec.CalculateWithOptions(ctx, holeCards, board, EquityOptions{Simulations: simulations, Progress: progress})
+}
 
-	// Build set of used cards
-	usedCards := make(map[string]bool)
-	for _, hole := range holeCards {
-		for _, c := range hole {
-			usedCards[cardKey(c)] = true
-		}
+// CalculateWithOptions runs Monte Carlo simulation as CalculateContext
+// does, configured by opts. It returns ErrInvalidSimulations unless
+// opts.Simulations is positive, and the errors of validateDeal for spots
+// that can't be dealt, such as ErrDuplicateCards if a card appears twice
+// across the hole cards, board and dead cards.
+func (ec *EquityCalculator) CalculateWithOptions(ctx context.Context, holeCards [][]Card, board []Card, opts EquityOptions) ([]EquityResult, error) {
+	if err := validateDeal(holeCards, board, opts.Dead); err != nil {
+		return nil, err
 	}
-	for _, c := range board {
-		usedCards[cardKey(c)] = true
+	if opts.Simulations <= 0 {
+		return nil, ErrInvalidSimulations
 	}
+	r := samplingRun(holeCards, board, opts.Simulations, opts)
+	return r.run(ctx, ec.workersFor(opts), opts.Progress)
+}
 
-	// Build remaining deck
-	remainingDeck := buildRemainingDeck(usedCards)
-	cardsNeeded := 5 - len(board)
+// CalculateExact calculates exact equity by enumerating all possible board runouts.
+// Only practical when few cards remain to be dealt (e.g., river only).
+// Returns a *TooManyCombinationsError if there are more than maxCombinations
+// runouts (no limit if maxCombinations <= 0), and the errors of
+// CalculateWithOptions for invalid spots.
+//
+// Runouts that differ only by suits no player or board card uses (or by suits
+// that can be swapped without changing any hand) play out the same, so only
+// one of each such group is evaluated and counted with the group's size.
+// Heads-up with an empty board, the counts are looked up in the precomputed
+// preflop table instead of enumerated.
+func (ec *EquityCalculator) CalculateExact(holeCards [][]Card, board []Card, maxCombinations int) ([]EquityResult, error) {
+	return ec.CalculateExactContext(context.Background(), holeCards, board, maxCombinations, nil)
+}
 
-	// Distribute simulations across workers
-	simsPerWorker := simulations / ec.workers
-	remainder := simulations % ec.workers
+// CalculateExactContext is CalculateExact with cancellation and progress
+// reporting, which behave as for CalculateContext. Progress and partial
+// results count boards in enumeration order, so a partial result is an exact
+// count over the boards reached rather than an unbiased estimate.
+func (ec *EquityCalculator) CalculateExactContext(ctx context.Context, holeCards [][]Card, board []Card, maxCombinations int, progress func(EquityProgress)) ([]EquityResult, error) {
+	return ec.CalculateExactWithOptions(ctx, holeCards, board, EquityOptions{MaxCombinations: maxCombinations, Progress: progress})
+}
 
-	var wg sync.WaitGroup
-	for w := 0; w < ec.workers; w++ {
-		workerSims := simsPerWorker
-		if w < remainder {
-			workerSims++
+// CalculateExactWithOptions enumerates every runout as CalculateExactContext
+// does, configured by opts, and validates the spot as CalculateWithOptions
+// does. It returns a *TooManyCombinationsError if there are more than
+// opts.MaxCombinations runouts. Without dead cards or breakdowns, heads-up
+// preflop spots come from the preflop table.
+func (ec *EquityCalculator) CalculateExactWithOptions(ctx context.Context, holeCards [][]Card, board []Card, opts EquityOptions) ([]EquityResult, error) {
+	if err := validateDeal(holeCards, board, opts.Dead); err != nil {
+		return nil, err
+	}
+	r := exactRun(holeCards, board, opts)
+	if opts.MaxCombinations > 0 && r.total > opts.MaxCombinations {
+		return nil, &TooManyCombinationsError{Combinations: r.total, Max: opts.MaxCombinations}
+	}
+	if len(opts.Dead) == 0 && !opts.Breakdown {
+		if results, ok := lookupPreflopEquity(holeCards, board); ok {
+			if opts.Progress != nil {
+				opts.Progress(EquityProgress{Completed: preflopBoards, Total: preflopBoards, Results: results})
+			}
+			return results, nil
 		}
-
-		wg.Add(1)
-		go func(numSims int) {
-			defer wg.Done()
-			ec.runSimulations(holeCards, board, remainingDeck, cardsNeeded, numSims, wins, ties)
-		}(workerSims)
 	}
+	return r.run(ctx, ec.workersFor(opts), opts.Progress)
+}
 
-	wg.Wait()
+// Precision is the stopping rule for CalculatePrecise.
+type Precision struct {
+	// Margin is the largest acceptable half-width of every player's
+	// confidence interval, e.g. 0.001 for ±0.1%. Zero runs until the time
+	// budget expires.
+	Margin float64
 
-	// Calculate final results
-	for i := 0; i < numPlayers; i++ {
-		w := int(atomic.LoadInt64(&wins[i]))
-		t := int(atomic.LoadInt64(&ties[i]))
-		l := simulations - w - t
+	// Confidence is the confidence level of the intervals; zero means 0.95.
+	Confidence float64
 
-		results[i] = EquityResult{
-			Wins:   w,
-			Ties:   t,
-			Losses: l,
-			Total:  simulations,
-			Equity: (float64(w) + float64(t)/float64(numPlayers)) / float64(simulations),
-		}
+	// TimeBudget stops sampling early when it expires; zero means no limit.
+	TimeBudget time.Duration
+}
+
+// minPreciseSimulations is the fewest simulations CalculatePrecise trusts to
+// estimate the variance of a result.
+const minPreciseSimulations = 10000
+
+// CalculatePrecise calculates equity to a requested precision instead of a
+// fixed number of simulations. It estimates the cost of enumerating every
+// board (after suit symmetries) against the simulations the margin needs at
+// worst-case variance and runs whichever is cheaper: exact enumeration,
+// whose results have no error, or sampling until every player's confidence
+// interval is within the margin.
+//
+// When the time budget expires, sampling stops with the precision reached so
+// far (see EquityResult.StdErr), while an unfinished enumeration is returned
+// as Partial. If ctx ends first the results are Partial and ctx's error is
+// returned. Progress reports estimate the total from the current variance.
+// Dead cards, the random source, workers and progress are taken from opts;
+// its simulation and combination limits are ignored.
+func (ec *EquityCalculator) CalculatePrecise(ctx context.Context, holeCards [][]Card, board []Card, precision Precision, opts EquityOptions) ([]EquityResult, error) {
+	if err := validateDeal(holeCards, board, opts.Dead); err != nil {
+		return nil, err
+	}
+	if precision.Margin < 0 || precision.Confidence < 0 || precision.Confidence >= 1 || (precision.Margin == 0 && precision.TimeBudget <= 0) {
+		return nil, ErrInvalidPrecision
 	}
+	confidence := precision.Confidence
+	if confidence == 0 {
+		confidence = 0.95
+	}
+	z := math.Sqrt2 * math.Erfinv(confidence)
 
-	return results
-}
+	runCtx := ctx
+	if precision.TimeBudget > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, precision.TimeBudget)
+		defer cancel()
+	}
 
-func (ec *EquityCalculator) runSimulations(
-	holeCards [][]Card,
-	board []Card,
-	deck []Card,
-	cardsNeeded int,
-	numSims int,
-	wins []int64,
-	ties []int64,
-) {
-	numPlayers := len(holeCards)
-	localDeck := make([]Card, len(deck))
-
-	for sim := 0; sim < numSims; sim++ {
-		// Copy and shuffle deck
-		copy(localDeck, deck)
-		shuffleDeck(localDeck)
-
-		// Complete the board
-		fullBoard := make([]Card, len(board), 5)
-		copy(fullBoard, board)
-		fullBoard = append(fullBoard, localDeck[:cardsNeeded]...)
-
-		// Evaluate each player's best hand
-		bestHands := make([]*Hand, numPlayers)
-		for i, hole := range holeCards {
-			allCards := make([]Card, 0, 7)
-			allCards = append(allCards, hole...)
-			allCards = append(allCards, fullBoard...)
-
-			best := findBestHand(allCards)
-			bestHands[i] = best
+	// Sampling needs (z/margin)^2 times the variance of a player's share of
+	// the pot, which is at most 1/4
+	exact := exactRun(holeCards, board, opts)
+	exactCost := exact.total / len(suitSymmetries(holeCards, board, opts.Dead))
+	if precision.Margin > 0 && float64(exactCost) <= math.Pow(z/precision.Margin, 2)/4 {
+		results, err := exact.run(runCtx, ec.workersFor(opts), opts.Progress)
+		if ctx.Err() == nil && err != nil {
+			err = nil // Out of time: the partial enumeration is flagged
 		}
+		return results, err
+	}
 
-		// Determine winners
-		winnerIndices := findWinnerIndices(bestHands)
+	r := samplingRun(holeCards, board, -1, opts)
+	r.target = func(t *equityTally) int {
+		if precision.Margin == 0 {
+			return math.MaxInt
+		}
+		needed := minPreciseSimulations
+		for i := range t.wins {
+			variance := t.variance(i)
+			needed = max(needed, int(math.Ceil(variance*math.Pow(z/precision.Margin, 2))))
+		}
+		return needed
+	}
 
-		if len(winnerIndices) == 1 {
-			atomic.AddInt64(&wins[winnerIndices[0]], 1)
-		} else {
-			for _, idx := range winnerIndices {
-				atomic.AddInt64(&ties[idx], 1)
-			}
+	results, err := r.run(runCtx, ec.workersFor(opts), opts.Progress)
+	if ctx.Err() == nil && err != nil {
+		// Out of time: the samples so far are still an unbiased estimate
+		for i := range results {
+			results[i].Partial = false
 		}
+		err = nil
 	}
+	return results, err
 }
 
-// CalculateExact calculates exact equity by enumerating all possible board runouts.
-// Only practical when few cards remain to be dealt (e.g., river only).
-// Returns nil if too many combinations (> maxCombinations).
-func (ec *EquityCalculator) CalculateExact(holeCards [][]Card, board []Card, maxCombinations int) []EquityResult {
-	// Build remaining deck
-	usedCards := make(map[string]bool)
-	for _, hole := range holeCards {
-		for _, c := range hole {
-			usedCards[cardKey(c)] = true
-		}
+// simulationBatch is a number of random runouts to sample, with the seed
+// for the worker's random source, so results don't depend on which worker
+// runs which batch.
+type simulationBatch struct {
+	simulations int
+	seed        int64
+}
+
+// samplingRun builds a Monte Carlo run of simulations random runouts, seeded
+// from opts.Rand (or the global source if nil). With negative simulations it
+// samples until the run's target is reached.
+func samplingRun(holeCards [][]Card, board []Card, simulations int, opts EquityOptions) *equityRun[simulationBatch] {
+	remainingDeck := buildRemainingDeck(usedCards(holeCards, board, opts.Dead))
+	cardsNeeded := 5 - len(board)
+	seed := rand.Int63
+	if opts.Rand != nil {
+		seed = opts.Rand.Int63
 	}
-	for _, c := range board {
-		usedCards[cardKey(c)] = true
+
+	return &equityRun[simulationBatch]{
+		numPlayers: len(holeCards),
+		total:      max(0, simulations),
+		sampled:    true,
+		breakdown:  opts.Breakdown,
+		batches: func(yield func(simulationBatch) bool) {
+			for done := 0; simulations < 0 || done < simulations; done += simulationBatchSize {
+				batch := simulationBatch{simulations: simulationBatchSize, seed: seed()}
+				if simulations >= 0 {
+					batch.simulations = min(batch.simulations, simulations-done)
+				}
+				if !yield(batch) {
+					return
+				}
+			}
+		},
+		newWorker: func() func(simulationBatch, *equityTally) {
+			rng := rand.New(rand.NewSource(1))
+			deck := append([]Card(nil), remainingDeck...)
+			fullBoard := append(append([]Card(nil), board...), make([]Card, cardsNeeded)...)
+			s := newShowdown(holeCards)
+			if opts.Breakdown {
+				s.withBreakdown(len(board), false)
+			}
+
+			return func(batch simulationBatch, tally *equityTally) {
+				rng.Seed(batch.seed)
+				copy(deck, remainingDeck)
+				for i := 0; i < batch.simulations; i++ {
+					dealCards(deck, fullBoard[len(board):], rng)
+					s.record(fullBoard, 1, tally)
+				}
+			}
+		},
 	}
+}
 
-	remainingDeck := buildRemainingDeck(usedCards)
+// runoutBatch holds runouts back to back, with the number of runouts each
+// one stands for.
+type runoutBatch struct {
+	cards   []Card
+	weights []int
+}
+
+// exactRun builds a run over every runout of the board, evaluating one of
+// each group of runouts that are the same up to suit symmetries.
+func exactRun(holeCards [][]Card, board []Card, opts EquityOptions) *equityRun[runoutBatch] {
+	remainingDeck := buildRemainingDeck(usedCards(holeCards, board, opts.Dead))
 	cardsNeeded := 5 - len(board)
+	symmetries := suitSymmetries(holeCards, board, opts.Dead)
+
+	return &equityRun[runoutBatch]{
+		numPlayers: len(holeCards),
+		total:      Binomial(len(remainingDeck), cardsNeeded),
+		breakdown:  opts.Breakdown,
+		batches: func(yield func(runoutBatch) bool) {
+			var batch runoutBatch
+			for runout := range CombinationsBuffer(remainingDeck, cardsNeeded, nil) {
+				weight := runoutWeight(runout, symmetries)
+				if weight == 0 {
+					continue
+				}
+				batch.cards = append(batch.cards, runout...)
+				batch.weights = append(batch.weights, weight)
+				if len(batch.weights) == runoutBatchSize {
+					if !yield(batch) {
+						return
+					}
+					batch = runoutBatch{}
+				}
+			}
+			if len(batch.weights) > 0 {
+				yield(batch)
+			}
+		},
+		newWorker: func() func(runoutBatch, *equityTally) {
+			fullBoard := append(append([]Card(nil), board...), make([]Card, cardsNeeded)...)
+			s := newShowdown(holeCards)
+			if opts.Breakdown {
+				s.withBreakdown(len(board), true)
+			}
 
-	// Check if enumeration is feasible
-	combos := Combinations(remainingDeck, cardsNeeded)
-	if len(combos) > maxCombinations {
-		return nil
+			return func(batch runoutBatch, tally *equityTally) {
+				for i, weight := range batch.weights {
+					copy(fullBoard[len(board):], batch.cards[i*cardsNeeded:])
+					s.record(fullBoard, weight, tally)
+				}
+			}
+		},
 	}
+}
 
-	numPlayers := len(holeCards)
-	wins := make([]int, numPlayers)
-	ties := make([]int, numPlayers)
-	total := len(combos)
+// equityRun describes work for the calculator's workers: batches of type B
+// that each worker, built by newWorker, evaluates into a tally.
+type equityRun[B any] struct {
+	numPlayers int
+	total      int  // Simulations or boards to evaluate
+	sampled    bool // Results are estimates with a standard error
+	breakdown  bool // Results carry an EquityBreakdown
+	batches    iter.Seq[B]
+	newWorker  func() func(B, *equityTally)
+
+	// target, if set, replaces total with the amount of work needed given
+	// the results so far
+	target func(*equityTally) int
+}
 
-	// Process combinations in parallel
-	type result struct {
-		winners []int
-	}
+// run shares the batches between workers and merges their tallies until the
+// work is done or ctx ends.
+func (r *equityRun[B]) run(ctx context.Context, workers int, progress func(EquityProgress)) ([]EquityResult, error) {
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	jobs := make(chan B)
+	tallies := make(chan *equityTally)
 
-	results := make(chan result, len(combos))
-	sem := make(chan struct{}, ec.workers)
-	var wg sync.WaitGroup
+	go func() {
+		defer close(jobs)
+		for batch := range r.batches {
+			select {
+			case jobs <- batch:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	for _, combo := range combos {
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
 		wg.Add(1)
-		go func(boardCards []Card) {
+		go func() {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			fullBoard := make([]Card, len(board), 5)
-			copy(fullBoard, board)
-			fullBoard = append(fullBoard, boardCards...)
-
-			bestHands := make([]*Hand, numPlayers)
-			for i, hole := range holeCards {
-				allCards := make([]Card, 0, 7)
-				allCards = append(allCards, hole...)
-				allCards = append(allCards, fullBoard...)
-				bestHands[i] = findBestHand(allCards)
+			work := r.newWorker()
+			for batch := range jobs {
+				tally := newEquityTally(r.numPlayers, r.sampled, r.breakdown)
+				work(batch, tally)
+				tallies <- tally
 			}
-
-			results <- result{findWinnerIndices(bestHands)}
-		}(combo)
+		}()
 	}
-
 	go func() {
 		wg.Wait()
-		close(results)
+		close(tallies)
 	}()
 
-	for r := range results {
-		if len(r.winners) == 1 {
-			wins[r.winners[0]]++
-		} else {
-			for _, idx := range r.winners {
-				ties[idx]++
-			}
+	// Report roughly every percent of the work
+	tally := newEquityTally(r.numPlayers, r.sampled, r.breakdown)
+	total := r.total
+	reported := 0
+	for t := range tallies {
+		tally.add(t)
+		if r.target != nil {
+			total = r.target(tally)
+		}
+		if tally.total >= total {
+			stop()
+			continue
 		}
+		if progress != nil && tally.total-reported >= max(1, total/100) {
+			progress(EquityProgress{Completed: tally.total, Total: total, Results: tally.results()})
+			reported = tally.total
+		}
+	}
+
+	complete := tally.total >= total
+	results := tally.results()
+	for i := range results {
+		results[i].Partial = !complete
+	}
+	if progress != nil {
+		progress(EquityProgress{Completed: tally.total, Total: max(total, tally.total), Results: results})
+	}
+	if !complete {
+		return results, context.Cause(ctx)
+	}
+	return results, nil
+}
+
+// equityTally counts weighted showdown outcomes for each player.
+type equityTally struct {
+	wins      []int
+	ties      []int
+	total     int
+	sampled   bool
+	breakdown *breakdownTally // Nil unless breakdowns were asked for
+}
+
+func newEquityTally(numPlayers int, sampled, breakdown bool) *equityTally {
+	t := &equityTally{wins: make([]int, numPlayers), ties: make([]int, numPlayers), sampled: sampled}
+	if breakdown {
+		t.breakdown = newBreakdownTally(numPlayers)
+	}
+	return t
+}
+
+// add merges another tally into this one.
+func (t *equityTally) add(other *equityTally) {
+	for i := range t.wins {
+		t.wins[i] += other.wins[i]
+		t.ties[i] += other.ties[i]
+	}
+	t.total += other.total
+	if t.breakdown != nil {
+		t.breakdown.add(other.breakdown)
+	}
+}
+
+// variance returns the sample variance of a player's share of each pot: 1
+// for a win, 1/numPlayers for a tie and 0 for a loss.
+func (t *equityTally) variance(i int) float64 {
+	if t.total == 0 {
+		return 0
 	}
+	tieShare := 1 / float64(len(t.wins))
+	mean := (float64(t.wins[i]) + float64(t.ties[i])*tieShare) / float64(t.total)
+	meanSquare := (float64(t.wins[i]) + float64(t.ties[i])*tieShare*tieShare) / float64(t.total)
+	return max(0, meanSquare-mean*mean)
+}
 
-	equityResults := make([]EquityResult, numPlayers)
-	for i := 0; i < numPlayers; i++ {
-		l := total - wins[i] - ties[i]
-		equityResults[i] = EquityResult{
-			Wins:   wins[i],
-			Ties:   ties[i],
-			Losses: l,
-			Total:  total,
-			Equity: (float64(wins[i]) + float64(ties[i])/float64(numPlayers)) / float64(total),
+// results converts the tally to equity results.
+func (t *equityTally) results() []EquityResult {
+	numPlayers := len(t.wins)
+	results := make([]EquityResult, numPlayers)
+	for i := range results {
+		results[i] = EquityResult{
+			Wins:   t.wins[i],
+			Ties:   t.ties[i],
+			Losses: t.total - t.wins[i] - t.ties[i],
+			Total:  t.total,
+		}
+		if t.total > 0 {
+			results[i].Equity = (float64(t.wins[i]) + float64(t.ties[i])/float64(numPlayers)) / float64(t.total)
+		}
+		if t.sampled && t.total > 0 {
+			results[i].StdErr = math.Sqrt(t.variance(i) / float64(t.total))
+		}
+		if t.breakdown != nil {
+			results[i].Breakdown = t.breakdown.breakdown(i, results[i])
 		}
 	}
+	return results
+}
 
-	return equityResults
+// showdown scores every player's hand on complete boards, reusing its
+// buffers so a worker doesn't allocate per board.
+type showdown struct {
+	holeCards [][]Card
+	cards     []Card
+	scores    []handScore
+
+	// For breakdowns: the size of the board before the runout, and the
+	// deals of each street to come, see streetDeals
+	boardLen    int
+	streetDeals map[int][][]int
+	street      []Card
+}
+
+func newShowdown(holeCards [][]Card) *showdown {
+	return &showdown{holeCards: holeCards, scores: make([]handScore, len(holeCards))}
+}
+
+// withBreakdown sets the showdown up to record breakdowns of runouts of a
+// board of boardLen cards.
+func (s *showdown) withBreakdown(boardLen int, exact bool) {
+	s.boardLen = boardLen
+	s.streetDeals = streetDeals(boardLen, exact)
+}
+
+// score scores every player's hand with the board into s.scores, and returns
+// the best score and how many players have it.
+func (s *showdown) score(board []Card) (best handScore, winners int) {
+	for i, hole := range s.holeCards {
+		s.cards = append(append(s.cards[:0], hole...), board...)
+		s.scores[i] = evaluateCards(s.cards)
+		switch {
+		case s.scores[i] > best:
+			best, winners = s.scores[i], 1
+		case s.scores[i] == best:
+			winners++
+		}
+	}
+	return best, winners
+}
+
+// record adds the outcome on a complete board to the tally, counted weight times.
+func (s *showdown) record(board []Card, weight int, tally *equityTally) {
+	best, winners := s.score(board)
+	for i, score := range s.scores {
+		switch {
+		case score != best:
+		case winners == 1:
+			tally.wins[i] += weight
+		default:
+			tally.ties[i] += weight
+		}
+	}
+	tally.total += weight
+
+	if tally.breakdown == nil {
+		return
+	}
+	for i, score := range s.scores {
+		outcome := &tally.breakdown.ranks[i][score.Rank()]
+		outcome.Count += weight
+		switch {
+		case score != best:
+		case winners == 1:
+			outcome.Wins += weight
+		default:
+			outcome.Ties += weight
+		}
+	}
+	s.recordStreets(board, weight, tally)
+}
+
+// lookupPreflopEquity returns exact heads-up preflop results from the
+// preflop table, counting every board. ok is false unless the spot is two
+// distinct sets of hole cards with an empty board.
+func lookupPreflopEquity(holeCards [][]Card, board []Card) (results []EquityResult, ok bool) {
+	if len(board) != 0 || len(holeCards) != 2 {
+		return nil, false
+	}
+	wins, ties, boards, ok := preflopEquities().MatchupResults(holeCards[0], holeCards[1])
+	if !ok {
+		return nil, false
+	}
+
+	losses := boards - wins - ties
+	results = []EquityResult{
+		{Wins: wins, Ties: ties, Losses: losses},
+		{Wins: losses, Ties: ties, Losses: wins},
+	}
+	for i := range results {
+		results[i].Total = boards
+		results[i].Equity = (float64(results[i].Wins) + float64(ties)/2) / float64(boards)
+	}
+	return results, true
 }
 
 // Helper functions
@@ -238,6 +632,74 @@ func cardKey(c Card) string {
 	return string(rune(c.Rank)) + string(rune(c.Suit))
 }
 
+// hasDuplicateCards returns true if a card appears more than once across the
+// groups of cards.
+func hasDuplicateCards(groups ...[]Card) bool {
+	seen := make(map[string]bool)
+	for _, cards := range groups {
+		for _, c := range cards {
+			if seen[cardKey(c)] {
+				return true
+			}
+			seen[cardKey(c)] = true
+		}
+	}
+	return false
+}
+
+// usedCards returns the set of cards dealt to players or the board, or dead.
+func usedCards(holeCards [][]Card, board, dead []Card) map[string]bool {
+	used := make(map[string]bool)
+	for _, cards := range append(append([][]Card(nil), holeCards...), board, dead) {
+		for _, c := range cards {
+			used[cardKey(c)] = true
+		}
+	}
+	return used
+}
+
+// validateDeal checks that an equity spot can be played out. It returns
+// ErrInvalidHoleCards unless every player has 2 hole cards,
+// ErrInvalidBoardSize unless the board is empty, a flop, turn or river,
+// ErrInvalidCard for cards outside the deck, ErrDuplicateCards if a card
+// appears twice across the hole cards, board and dead cards, and ErrEmptyDeck
+// if too few cards are left to complete the board.
+func validateDeal(holeCards [][]Card, board, dead []Card) error {
+	if len(holeCards) == 0 {
+		return ErrInvalidHoleCards
+	}
+	for _, hole := range holeCards {
+		if len(hole) != 2 {
+			return ErrInvalidHoleCards
+		}
+	}
+	if n := len(board); n != 0 && (n < 3 || n > 5) {
+		return ErrInvalidBoardSize
+	}
+
+	var seen cardSet
+	for _, cards := range append(append([][]Card(nil), holeCards...), board, dead) {
+		for _, c := range cards {
+			if !validCard(c) {
+				return ErrInvalidCard
+			}
+			if seen&cardBit(c) != 0 {
+				return ErrDuplicateCards
+			}
+			seen |= cardBit(c)
+		}
+	}
+	if bits.OnesCount64(uint64(seen))+5-len(board) > 52 {
+		return ErrEmptyDeck
+	}
+	return nil
+}
+
+// validCard reports whether a card has a real rank and suit.
+func validCard(c Card) bool {
+	return c.Rank >= Two && c.Rank <= Ace && c.Suit >= Clubs && c.Suit <= Spades
+}
+
 func buildRemainingDeck(usedCards map[string]bool) []Card {
 	deck := make([]Card, 0, 52-len(usedCards))
 	for _, suit := range AllSuits() {
@@ -251,17 +713,11 @@ func buildRemainingDeck(usedCards map[string]bool) []Card {
 	return deck
 }
 
-func shuffleDeck(deck []Card) {
-	rand.Shuffle(len(deck), func(i, j int) {
-		deck[i], deck[j] = deck[j], deck[i]
-	})
-}
-
 func findBestHand(cards []Card) *Hand {
-	combos := CardCombinations(cards, 5)
 	var best *Hand
+	var buf [handSize]Card
 
-	for _, combo := range combos {
+	for combo := range CombinationsBuffer(cards, handSize, buf[:]) {
 		hand, err := NewHand(combo)
 		if err != nil {
 			continue
@@ -1,8 +1,12 @@
 package goker
 
 import (
+	"context"
+	"errors"
 	"math"
+	"math/rand"
 	"testing"
+	"time"
 )
 
 func TestNewEquityCalculator(t *testing.T) {
@@ -26,7 +30,10 @@ func TestEquityCalculatorBasic(t *testing.T) {
 		{NewCard(King, Spades), NewCard(King, Hearts)},
 	}
 
-	results := ec.Calculate(holeCards, []Card{}, 1000)
+	results, err := ec.Calculate(holeCards, []Card{}, 1000)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
 
 	if len(results) != 2 {
 		t.Fatalf("Expected 2 results, got %d", len(results))
@@ -64,7 +71,10 @@ func TestEquityCalculatorWithBoard(t *testing.T) {
 		NewCard(Five, Hearts),
 	}
 
-	results := ec.Calculate(holeCards, board, 1000)
+	results, err := ec.Calculate(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
 
 	// Set should dominate overpair
 	if results[0].Equity < results[1].Equity {
@@ -82,7 +92,10 @@ func TestEquityCalculatorMultiplePlayers(t *testing.T) {
 		{NewCard(Queen, Spades), NewCard(Queen, Hearts)},
 	}
 
-	results := ec.Calculate(holeCards, []Card{}, 2000)
+	results, err := ec.Calculate(holeCards, []Card{}, 2000)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
 
 	if len(results) != 3 {
 		t.Fatalf("Expected 3 results, got %d", len(results))
@@ -122,7 +135,10 @@ func TestEquityCalculatorExact(t *testing.T) {
 		NewCard(Jack, Spades),
 	}
 
-	results := ec.CalculateExact(holeCards, board, 1000)
+	results, err := ec.CalculateExact(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
 
 	if results == nil {
 		t.Fatal("CalculateExact returned nil")
@@ -149,11 +165,430 @@ func TestEquityCalculatorExactTooMany(t *testing.T) {
 		{NewCard(King, Spades), NewCard(King, Hearts)},
 	}
 
-	results := ec.CalculateExact(holeCards, []Card{}, 1000)
+	results, err := ec.CalculateExact(holeCards, []Card{}, 1000)
 
 	if results != nil {
 		t.Error("CalculateExact should return nil when too many combinations")
 	}
+	var tooMany *TooManyCombinationsError
+	if !errors.As(err, &tooMany) || !errors.Is(err, ErrTooManyCombinations) {
+		t.Fatalf("CalculateExact error = %v, want a TooManyCombinationsError", err)
+	}
+	if tooMany.Combinations != 1712304 || tooMany.Max != 1000 {
+		t.Errorf("TooManyCombinationsError = %+v, want 1712304 combinations over 1000", tooMany)
+	}
+}
+
+func TestEquityCalculatorExactRiver(t *testing.T) {
+	ec := NewEquityCalculator(2)
+
+	// Nothing left to deal: the board plays out once
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Spades), NewCard(King, Hearts)},
+	}
+	board := []Card{
+		NewCard(Two, Clubs),
+		NewCard(Five, Diamonds),
+		NewCard(Nine, Hearts),
+		NewCard(Jack, Spades),
+		NewCard(King, Clubs),
+	}
+
+	results, err := ec.CalculateExact(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
+	if results == nil {
+		t.Fatal("CalculateExact returned nil")
+	}
+	if results[0].Total != 1 || results[1].Wins != 1 {
+		t.Errorf("CalculateExact on the river = %+v, want 1 board won by KK", results)
+	}
+}
+
+func TestEquityCalculatorContextCancelled(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Spades), NewCard(King, Hearts)},
+		{NewCard(Queen, Spades), NewCard(Queen, Hearts)},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := ec.CalculateContext(ctx, holeCards, nil, 1000000, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CalculateContext error = %v, want context.Canceled", err)
+	}
+	if len(results) != 3 || !results[0].Partial || results[0].Total >= 1000000 {
+		t.Errorf("CalculateContext after cancellation = %+v, want partial results", results)
+	}
+}
+
+func TestEquityCalculatorExactDeadline(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(King, Spades)},
+		{NewCard(Queen, Hearts), NewCard(Jack, Diamonds)},
+		{NewCard(Seven, Clubs), NewCard(Two, Hearts)},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, err := ec.CalculateExactContext(ctx, holeCards, nil, 2000000, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CalculateExactContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CalculateExactContext took %v to stop after the deadline", elapsed)
+	}
+	if !results[0].Partial || results[0].Total >= Binomial(46, 5) {
+		t.Errorf("CalculateExactContext after the deadline = %+v, want partial results", results[0])
+	}
+	if results[0].Wins+results[0].Ties+results[0].Losses != results[0].Total {
+		t.Errorf("partial counts %+v don't add up to the total", results[0])
+	}
+}
+
+func TestEquityCalculatorProgress(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Clubs), NewCard(King, Diamonds)},
+	}
+	board := []Card{NewCard(Two, Clubs), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)}
+
+	var reports []EquityProgress
+	results, err := ec.CalculateContext(context.Background(), holeCards, board, 50000, func(p EquityProgress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("CalculateContext error: %v", err)
+	}
+	if len(reports) < 2 {
+		t.Fatalf("got %d progress reports, want several", len(reports))
+	}
+	for i, p := range reports {
+		if p.Total != 50000 || (i > 0 && p.Completed <= reports[i-1].Completed) {
+			t.Fatalf("progress report %d = %d of %d, want increasing counts of 50000", i, p.Completed, p.Total)
+		}
+		if p.Results[0].Total != p.Completed {
+			t.Errorf("progress report %d estimates from %d simulations, want %d", i, p.Results[0].Total, p.Completed)
+		}
+	}
+	last := reports[len(reports)-1]
+	if last.Completed != 50000 || last.Results[0] != results[0] || results[0].Partial {
+		t.Errorf("final progress report = %+v, want the complete results %+v", last, results[0])
+	}
+}
+
+func TestEquityCalculatorExactPreflop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every preflop board")
+	}
+	ec := NewEquityCalculator(0)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Spades), NewCard(King, Hearts)},
+	}
+
+	// A breakdown enumerates the boards rather than using the preflop table
+	results, err := ec.CalculateExactWithOptions(context.Background(), holeCards, nil, EquityOptions{Breakdown: true})
+	if err != nil {
+		t.Fatalf("CalculateExactWithOptions error: %v", err)
+	}
+	if results == nil {
+		t.Fatal("CalculateExactWithOptions returned nil")
+	}
+	if results[0].Total != Binomial(48, 5) {
+		t.Errorf("Total = %d, want %d", results[0].Total, Binomial(48, 5))
+	}
+	if math.Abs(results[0].Equity-0.8264) > 0.0001 {
+		t.Errorf("AA vs KK exact equity = %.4f, want 0.8264", results[0].Equity)
+	}
+
+	table, err := ec.CalculateExact(holeCards, nil, Binomial(48, 5))
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
+	for i := range results {
+		if table[i].Wins != results[i].Wins || table[i].Ties != results[i].Ties || table[i].Total != results[i].Total {
+			t.Errorf("player %d from the preflop table = %+v, enumerated %+v", i, table[i], results[i])
+		}
+	}
+}
+
+func TestEquityStandardError(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Clubs), NewCard(King, Diamonds)},
+	}
+	board := []Card{NewCard(Two, Clubs), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)}
+
+	// A fixed seed keeps the sampled interval from missing the exact equity
+	// on the occasional unlucky run
+	opts := EquityOptions{Rand: rand.New(rand.NewSource(1)), Simulations: 20000}
+	sampled, err := ec.CalculateWithOptions(context.Background(), holeCards, board, opts)
+	if err != nil {
+		t.Fatalf("CalculateWithOptions error: %v", err)
+	}
+	for i, r := range sampled {
+		want := math.Sqrt(r.Equity * (1 - r.Equity) / 20000)
+		if math.Abs(r.StdErr-want) > want*0.1 {
+			t.Errorf("player %d StdErr = %.5f, want about %.5f", i, r.StdErr, want)
+		}
+	}
+
+	exact, err := ec.CalculateExact(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
+	if exact[0].StdErr != 0 {
+		t.Errorf("exact StdErr = %v, want 0", exact[0].StdErr)
+	}
+	low, high := sampled[0].ConfidenceInterval(0.99)
+	if exact[0].Equity < low || exact[0].Equity > high {
+		t.Errorf("exact equity %.4f outside the 99%% interval [%.4f, %.4f]", exact[0].Equity, low, high)
+	}
+}
+
+func TestEquityResultConfidenceInterval(t *testing.T) {
+	tests := []struct {
+		result     EquityResult
+		confidence float64
+		low, high  float64
+	}{
+		{EquityResult{Equity: 0.5, StdErr: 0.01}, 0.95, 0.4804, 0.5196},
+		{EquityResult{Equity: 0.5, StdErr: 0.01}, 0.99, 0.4742, 0.5258},
+		{EquityResult{Equity: 0.99, StdErr: 0.01}, 0.95, 0.9704, 1},
+		{EquityResult{Equity: 0.3}, 0.95, 0.3, 0.3},
+	}
+
+	for _, tt := range tests {
+		low, high := tt.result.ConfidenceInterval(tt.confidence)
+		if math.Abs(low-tt.low) > 0.0001 || math.Abs(high-tt.high) > 0.0001 {
+			t.Errorf("%+v.ConfidenceInterval(%v) = [%.4f, %.4f], want [%.4f, %.4f]",
+				tt.result, tt.confidence, low, high, tt.low, tt.high)
+		}
+	}
+}
+
+func TestCalculatePrecise(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	ctx := context.Background()
+
+	t.Run("exact when cheaper", func(t *testing.T) {
+		holeCards := [][]Card{
+			{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+			{NewCard(King, Clubs), NewCard(King, Diamonds)},
+		}
+		board := []Card{NewCard(Two, Clubs), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)}
+
+		results, err := ec.CalculatePrecise(ctx, holeCards, board, Precision{Margin: 0.001}, EquityOptions{})
+		if err != nil {
+			t.Fatalf("CalculatePrecise error: %v", err)
+		}
+		if results[0].Total != 990 || results[0].StdErr != 0 || results[0].Partial {
+			t.Errorf("CalculatePrecise on the flop = %+v, want exact results over 990 boards", results[0])
+		}
+	})
+
+	t.Run("sampled to margin", func(t *testing.T) {
+		holeCards := [][]Card{
+			{NewCard(Ace, Spades), NewCard(King, Spades)},
+			{NewCard(Queen, Hearts), NewCard(Jack, Diamonds)},
+		}
+
+		results, err := ec.CalculatePrecise(ctx, holeCards, nil, Precision{Margin: 0.005, Confidence: 0.95}, EquityOptions{})
+		if err != nil {
+			t.Fatalf("CalculatePrecise error: %v", err)
+		}
+		for i, r := range results {
+			low, high := r.ConfidenceInterval(0.95)
+			if r.StdErr == 0 || r.Partial || high-r.Equity > 0.005 || r.Equity-low > 0.005 {
+				t.Errorf("player %d = %+v with interval [%.4f, %.4f], want a sampled result within ±0.005", i, r, low, high)
+			}
+		}
+		if results[0].Total < minPreciseSimulations || results[0].Total > 100000 {
+			t.Errorf("CalculatePrecise ran %d simulations, want roughly 38,000", results[0].Total)
+		}
+	})
+
+	t.Run("time budget", func(t *testing.T) {
+		holeCards := [][]Card{
+			{NewCard(Ace, Spades), NewCard(King, Spades)},
+			{NewCard(Queen, Hearts), NewCard(Jack, Diamonds)},
+			{NewCard(Seven, Clubs), NewCard(Seven, Diamonds)},
+		}
+
+		start := time.Now()
+		results, err := ec.CalculatePrecise(ctx, holeCards, nil, Precision{TimeBudget: 30 * time.Millisecond}, EquityOptions{})
+		if err != nil {
+			t.Fatalf("CalculatePrecise error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("CalculatePrecise took %v with a 30ms budget", elapsed)
+		}
+		if results[0].Total == 0 || results[0].Partial || results[0].StdErr == 0 {
+			t.Errorf("CalculatePrecise with a time budget = %+v, want a sampled result", results[0])
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		holeCards := [][]Card{
+			{NewCard(Ace, Spades), NewCard(King, Spades)},
+			{NewCard(Queen, Hearts), NewCard(Jack, Diamonds)},
+		}
+		for _, p := range []Precision{{}, {Margin: -1}, {Margin: 0.01, Confidence: 1}} {
+			if _, err := ec.CalculatePrecise(ctx, holeCards, nil, p, EquityOptions{}); !errors.Is(err, ErrInvalidPrecision) {
+				t.Errorf("CalculatePrecise(%+v) error = %v, want ErrInvalidPrecision", p, err)
+			}
+		}
+	})
+}
+
+func TestEquityDeadCards(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	ctx := context.Background()
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+		{NewCard(Queen, Spades), NewCard(Queen, Clubs)},
+	}
+	board := []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs)}
+	dead := []Card{NewCard(Three, Hearts), NewCard(Four, Hearts), NewCard(Five, Hearts)}
+
+	live, err := ec.CalculateExactWithOptions(ctx, holeCards, board, EquityOptions{})
+	if err != nil {
+		t.Fatalf("CalculateExactWithOptions error: %v", err)
+	}
+	folded, err := ec.CalculateExactWithOptions(ctx, holeCards, board, EquityOptions{Dead: dead})
+	if err != nil {
+		t.Fatalf("CalculateExactWithOptions with dead cards error: %v", err)
+	}
+
+	if folded[0].Total != Binomial(42, 2) {
+		t.Errorf("Total with 3 dead cards = %d, want %d", folded[0].Total, Binomial(42, 2))
+	}
+	if folded[0].Equity >= live[0].Equity {
+		t.Errorf("flush draw equity with dead flush cards = %.4f, want less than %.4f", folded[0].Equity, live[0].Equity)
+	}
+
+	// Sampling agrees with enumeration
+	sampled, err := ec.CalculateWithOptions(ctx, holeCards, board, EquityOptions{Dead: dead, Simulations: 50000})
+	if err != nil {
+		t.Fatalf("CalculateWithOptions with dead cards error: %v", err)
+	}
+	if math.Abs(sampled[0].Equity-folded[0].Equity) > 4*sampled[0].StdErr {
+		t.Errorf("sampled equity %.4f ± %.4f, want about %.4f", sampled[0].Equity, sampled[0].StdErr, folded[0].Equity)
+	}
+}
+
+func TestEquityDeadCardsPreflop(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Spades), NewCard(King, Hearts)},
+	}
+	dead := []Card{NewCard(King, Clubs), NewCard(King, Diamonds)}
+
+	// Dead cards bypass the preflop table
+	results, err := ec.CalculateWithOptions(context.Background(), holeCards, nil, EquityOptions{Dead: dead, Simulations: 20000})
+	if err != nil {
+		t.Fatalf("CalculateWithOptions error: %v", err)
+	}
+	if results[0].Total != 20000 || results[1].Equity > 0.2 {
+		t.Errorf("KK with the other kings dead = %+v, want a sampled result well below 20%%", results[1])
+	}
+}
+
+func TestEquityValidation(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	ctx := context.Background()
+	aces := []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)}
+	kings := []Card{NewCard(King, Spades), NewCard(King, Hearts)}
+
+	manyPlayers := make([][]Card, 0, 24)
+	for _, rank := range AllRanks()[:12] {
+		manyPlayers = append(manyPlayers,
+			[]Card{NewCard(rank, Clubs), NewCard(rank, Diamonds)},
+			[]Card{NewCard(rank, Hearts), NewCard(rank, Spades)})
+	}
+
+	tests := []struct {
+		name      string
+		holeCards [][]Card
+		board     []Card
+		dead      []Card
+		expected  error
+	}{
+		{"dead card in hole cards", [][]Card{aces, kings}, nil, []Card{NewCard(Ace, Spades)}, ErrDuplicateCards},
+		{"board card in hole cards", [][]Card{aces, kings}, []Card{NewCard(King, Hearts), NewCard(Two, Clubs), NewCard(Three, Clubs)}, nil, ErrDuplicateCards},
+		{"dead card on board", [][]Card{aces, kings}, []Card{NewCard(Two, Clubs), NewCard(Three, Clubs), NewCard(Four, Clubs)}, []Card{NewCard(Two, Clubs)}, ErrDuplicateCards},
+		{"shared hole cards", [][]Card{aces, aces}, nil, nil, ErrDuplicateCards},
+		{"invalid card", [][]Card{aces, {NewCard(15, Spades), NewCard(King, Clubs)}}, nil, nil, ErrInvalidCard},
+		{"no hole cards", [][]Card{aces, {}}, nil, nil, ErrInvalidHoleCards},
+		{"no players", nil, nil, nil, ErrInvalidHoleCards},
+		{"board too long", [][]Card{aces, kings}, NewDeck().Remaining()[:6], nil, ErrInvalidBoardSize},
+		{"partial flop", [][]Card{aces, kings}, []Card{NewCard(Two, Clubs)}, nil, ErrInvalidBoardSize},
+		{"three hole cards", [][]Card{aces, append([]Card{NewCard(Two, Clubs)}, kings...)}, nil, nil, ErrInvalidHoleCards},
+		{"deck runs out", manyPlayers, nil, nil, ErrEmptyDeck},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := EquityOptions{Dead: tt.dead, Simulations: 100}
+			if _, err := ec.CalculateWithOptions(ctx, tt.holeCards, tt.board, opts); !errors.Is(err, tt.expected) {
+				t.Errorf("CalculateWithOptions error = %v, want %v", err, tt.expected)
+			}
+			if _, err := ec.CalculateExactWithOptions(ctx, tt.holeCards, tt.board, opts); !errors.Is(err, tt.expected) {
+				t.Errorf("CalculateExactWithOptions error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEquityInvalidSimulations(t *testing.T) {
+	ec := NewEquityCalculator(2)
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		{NewCard(King, Spades), NewCard(King, Hearts)},
+	}
+
+	for _, simulations := range []int{0, -10} {
+		if _, err := ec.Calculate(holeCards, nil, simulations); !errors.Is(err, ErrInvalidSimulations) {
+			t.Errorf("Calculate with %d simulations error = %v, want ErrInvalidSimulations", simulations, err)
+		}
+	}
+}
+
+func TestEquitySeededRand(t *testing.T) {
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(King, Spades)},
+		{NewCard(Queen, Hearts), NewCard(Jack, Diamonds)},
+		{NewCard(Seven, Clubs), NewCard(Seven, Diamonds)},
+	}
+
+	var previous []EquityResult
+	for _, workers := range []int{1, 3} {
+		opts := EquityOptions{Rand: rand.New(rand.NewSource(42)), Simulations: 10000, Workers: workers}
+		results, err := NewEquityCalculator(2).CalculateWithOptions(context.Background(), holeCards, nil, opts)
+		if err != nil {
+			t.Fatalf("CalculateWithOptions error: %v", err)
+		}
+		if previous != nil {
+			for i := range results {
+				if results[i] != previous[i] {
+					t.Errorf("with %d workers player %d = %+v, want %+v as with 1", workers, i, results[i], previous[i])
+				}
+			}
+		}
+		previous = results
+	}
 }
 
 func TestEquityResultFields(t *testing.T) {
@@ -164,7 +599,10 @@ func TestEquityResultFields(t *testing.T) {
 		{NewCard(Two, Spades), NewCard(Seven, Hearts)},
 	}
 
-	results := ec.Calculate(holeCards, []Card{}, 100)
+	results, err := ec.Calculate(holeCards, []Card{}, 100)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
 
 	for i, r := range results {
 		// Wins + Ties + Losses should equal Total
@@ -1,6 +1,9 @@
 package goker
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrEmptyDeck is returned when attempting to draw from an empty deck.
@@ -12,9 +15,91 @@ var (
 	// ErrDuplicateCards is returned when a hand contains duplicate cards.
 	ErrDuplicateCards = errors.New("hand contains duplicate cards")
 
-	// ErrInvalidHoleCards is returned when hole cards don't have exactly 2 cards.
-	ErrInvalidHoleCards = errors.New("hole cards must contain exactly 2 cards")
+	// ErrInvalidHoleCards is returned when hole cards don't have the number of cards the game requires.
+	ErrInvalidHoleCards = errors.New("wrong number of hole cards")
 
 	// ErrInvalidBoardState is returned when board operation is invalid for current state.
 	ErrInvalidBoardState = errors.New("invalid board state for this operation")
+
+	// ErrInvalidOFCRow is returned when an OFC row has the wrong number of cards.
+	ErrInvalidOFCRow = errors.New("ofc top row must contain 3 cards and middle and bottom rows 5 cards")
+
+	// ErrInvalidThreeCardHandSize is returned when a 3-card hand doesn't have exactly 3 cards.
+	ErrInvalidThreeCardHandSize = errors.New("three card hand must contain exactly 3 cards")
+
+	// ErrInvalidBadugiHandSize is returned when a Badugi hand doesn't have exactly 4 cards.
+	ErrInvalidBadugiHandSize = errors.New("badugi hand must contain exactly 4 cards")
+
+	// ErrUnsupportedRanking is returned when a game's rules give no evaluators to resolve a showdown.
+	ErrUnsupportedRanking = errors.New("unsupported hand ranking for showdown")
+
+	// ErrInvalidRunouts is returned when asked to run the board out fewer than once.
+	ErrInvalidRunouts = errors.New("number of runouts must be at least 1")
+
+	// ErrInvalidSeat is returned when a seat number is out of range or unoccupied.
+	ErrInvalidSeat = errors.New("invalid seat")
+
+	// ErrSeatTaken is returned when sitting a player in an occupied seat.
+	ErrSeatTaken = errors.New("seat is already taken")
+
+	// ErrNotEnoughPlayers is returned when starting a hand with fewer than 2 players with chips.
+	ErrNotEnoughPlayers = errors.New("at least 2 players with chips are required")
+
+	// ErrInvalidBlindLevel is returned when a blind schedule is empty or a level can never end.
+	ErrInvalidBlindLevel = errors.New("invalid blind level schedule")
+
+	// ErrInvalidStacks is returned when chip stacks are negative or nobody has chips.
+	ErrInvalidStacks = errors.New("stacks must be non-negative with at least one player holding chips")
+
+	// ErrInvalidPayouts is returned when a payout structure is empty or has negative payouts.
+	ErrInvalidPayouts = errors.New("payouts must be non-empty and non-negative")
+
+	// ErrFieldTooLarge is returned when exact ICM would need too many finishing orders.
+	ErrFieldTooLarge = errors.New("field too large for exact ICM")
+
+	// ErrInvalidStartingHand is returned when a starting hand or range can't be parsed.
+	ErrInvalidStartingHand = errors.New("invalid starting hand")
+
+	// ErrInvalidPreflopTable is returned when decoding data that isn't a preflop table.
+	ErrInvalidPreflopTable = errors.New("invalid preflop table data")
+
+	// ErrInvalidBoardSize is returned when a board isn't a flop, turn or river (or empty, where allowed).
+	ErrInvalidBoardSize = errors.New("board must contain 3, 4 or 5 cards")
+
+	// ErrInvalidPrecision is returned when an equity precision target has no margin or time budget, or a confidence outside (0, 1).
+	ErrInvalidPrecision = errors.New("invalid equity precision target")
+
+	// ErrInvalidCard is returned when a card has a rank or suit outside the deck.
+	ErrInvalidCard = errors.New("invalid card")
+
+	// ErrInvalidSimulations is returned when asked to run fewer than one simulation.
+	ErrInvalidSimulations = errors.New("number of simulations must be at least 1")
+
+	// ErrTooManyCombinations is matched by a TooManyCombinationsError with errors.Is.
+	ErrTooManyCombinations = errors.New("too many combinations to enumerate")
+
+	// ErrEmptyRange is returned when a range has no combinations left to analyze.
+	ErrEmptyRange = errors.New("range has no combinations")
+
+	// ErrInvalidDenomination is returned when coloring up chips of a denomination that isn't positive.
+	ErrInvalidDenomination = errors.New("chip denominations must be positive")
+
+	// ErrNoEligiblePlayers is returned when a pot is split among no players.
+	ErrNoEligiblePlayers = errors.New("pot has no eligible players")
 )
+
+// TooManyCombinationsError is returned when exact enumeration would run
+// through more combinations than allowed.
+type TooManyCombinationsError struct {
+	Combinations int // Combinations the enumeration needs
+	Max          int // Most combinations allowed
+}
+
+func (e *TooManyCombinationsError) Error() string {
+	return fmt.Sprintf("%v: %d combinations, limit %d", ErrTooManyCombinations, e.Combinations, e.Max)
+}
+
+// Is reports whether target is ErrTooManyCombinations.
+func (e *TooManyCombinationsError) Is(target error) bool {
+	return target == ErrTooManyCombinations
+}
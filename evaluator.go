@@ -0,0 +1,134 @@
+package goker
+
+// handScore is the value of the best 5-card hand that can be made from a set
+// of cards: the HandRank in the top bits and the deciding card ranks below,
+// so a higher score is a better hand and equal scores tie.
+type handScore uint32
+
+// handScoreRankShift is the bit offset of the HandRank within a handScore.
+const handScoreRankShift = 20
+
+// Rank returns the rank of the scored hand.
+func (s handScore) Rank() HandRank {
+	return HandRank(s >> handScoreRankShift)
+}
+
+// evaluateCards scores the best 5-card hand in 5 to 7 cards without building
+// Hands, for use in hot loops. It orders hands the same way as comparing the
+// results of findBestHand with Hand.Compare.
+func evaluateCards(cards []Card) handScore {
+	var counts [Ace + 1]int
+	var suitRanks [4]int
+	var suitCounts [4]int
+	ranks := 0
+
+	for _, c := range cards {
+		counts[c.Rank]++
+		suitRanks[c.Suit] |= 1 << c.Rank
+		suitCounts[c.Suit]++
+		ranks |= 1 << c.Rank
+	}
+
+	for suit, n := range suitCounts {
+		if n < handSize {
+			continue
+		}
+		if high := straightHigh(suitRanks[suit]); high != 0 {
+			if high == Ace {
+				return newHandScore(RoyalFlush, high)
+			}
+			return newHandScore(StraightFlush, high)
+		}
+		return newHandScore(Flush, topRanks(suitRanks[suit], handSize)...)
+	}
+
+	var quads, trips, pairs []CardRank
+	for r := Ace; r >= Two; r-- {
+		switch counts[r] {
+		case 4:
+			quads = append(quads, r)
+		case 3:
+			trips = append(trips, r)
+		case 2:
+			pairs = append(pairs, r)
+		}
+	}
+
+	switch {
+	case len(quads) > 0:
+		return newHandScore(FourOfAKind, quads[0], quads[0], quads[0], quads[0], kicker(ranks, quads[0]))
+	case len(trips) > 0 && (len(trips) > 1 || len(pairs) > 0):
+		pair := CardRank(0)
+		if len(pairs) > 0 {
+			pair = pairs[0]
+		}
+		if len(trips) > 1 && trips[1] > pair {
+			pair = trips[1]
+		}
+		return newHandScore(FullHouse, trips[0], trips[0], trips[0], pair, pair)
+	}
+
+	if high := straightHigh(ranks); high != 0 {
+		return newHandScore(Straight, high)
+	}
+
+	switch {
+	case len(trips) > 0:
+		kickers := topRanks(ranks&^(1<<trips[0]), 2)
+		return newHandScore(ThreeOfAKind, trips[0], trips[0], trips[0], kickers[0], kickers[1])
+	case len(pairs) > 1:
+		return newHandScore(TwoPair, pairs[0], pairs[0], pairs[1], pairs[1], kicker(ranks, pairs[0], pairs[1]))
+	case len(pairs) == 1:
+		kickers := topRanks(ranks&^(1<<pairs[0]), 3)
+		return newHandScore(Pair, pairs[0], pairs[0], kickers[0], kickers[1], kickers[2])
+	default:
+		return newHandScore(HighCard, topRanks(ranks, handSize)...)
+	}
+}
+
+// newHandScore packs a hand rank and up to five ranks, most significant first.
+func newHandScore(rank HandRank, cards ...CardRank) handScore {
+	score := handScore(rank) << handScoreRankShift
+	for i, r := range cards {
+		score |= handScore(r) << tiebreakerShifts[i]
+	}
+	return score
+}
+
+// straightHigh returns the highest card of the best straight in a rank bit
+// set, 5 for the wheel, or 0 if there is none.
+func straightHigh(ranks int) CardRank {
+	for high := Ace; high >= Six; high-- {
+		run := straightValue << (high - 4)
+		if ranks&run == run {
+			return high
+		}
+	}
+	if ranks&wheelStraightValue == wheelStraightValue {
+		return Five
+	}
+	return 0
+}
+
+// topRanks returns the n highest ranks in a rank bit set.
+func topRanks(ranks, n int) []CardRank {
+	top := make([]CardRank, 0, n)
+	for r := Ace; r >= Two && len(top) < n; r-- {
+		if ranks&(1<<r) != 0 {
+			top = append(top, r)
+		}
+	}
+	return top
+}
+
+// kicker returns the highest rank in a rank bit set other than the excluded ones.
+func kicker(ranks int, excluded ...CardRank) CardRank {
+	for _, r := range excluded {
+		ranks &^= 1 << r
+	}
+	top := topRanks(ranks, 1)
+	if len(top) == 0 {
+		return 0
+	}
+	return top[0]
+}
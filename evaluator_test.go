@@ -0,0 +1,69 @@
+package goker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEvaluateCardsRanks(t *testing.T) {
+	tests := []struct {
+		cards    []Card
+		expected HandRank
+	}{
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Jack, Spades), NewCard(Ten, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs)}, RoyalFlush},
+		{[]Card{NewCard(Ace, Hearts), NewCard(Two, Hearts), NewCard(Three, Hearts), NewCard(Four, Hearts), NewCard(Five, Hearts), NewCard(King, Clubs), NewCard(King, Spades)}, StraightFlush},
+		{[]Card{NewCard(Nine, Clubs), NewCard(Nine, Diamonds), NewCard(Nine, Hearts), NewCard(Nine, Spades), NewCard(Two, Clubs), NewCard(Two, Hearts), NewCard(Two, Spades)}, FourOfAKind},
+		{[]Card{NewCard(Nine, Clubs), NewCard(Nine, Diamonds), NewCard(Nine, Hearts), NewCard(Two, Spades), NewCard(Two, Clubs), NewCard(Two, Hearts), NewCard(Ace, Spades)}, FullHouse},
+		{[]Card{NewCard(Ace, Clubs), NewCard(Nine, Clubs), NewCard(Seven, Clubs), NewCard(Four, Clubs), NewCard(Two, Clubs), NewCard(Three, Hearts), NewCard(Five, Spades)}, Flush},
+		{[]Card{NewCard(Ace, Clubs), NewCard(Two, Diamonds), NewCard(Three, Clubs), NewCard(Four, Hearts), NewCard(Five, Clubs)}, Straight},
+		{[]Card{NewCard(Seven, Clubs), NewCard(Seven, Diamonds), NewCard(Seven, Hearts), NewCard(King, Hearts), NewCard(Two, Clubs), NewCard(Four, Hearts)}, ThreeOfAKind},
+		{[]Card{NewCard(Seven, Clubs), NewCard(Seven, Diamonds), NewCard(Three, Hearts), NewCard(Three, Clubs), NewCard(Two, Clubs), NewCard(Two, Hearts), NewCard(Ace, Spades)}, TwoPair},
+		{[]Card{NewCard(Seven, Clubs), NewCard(Seven, Diamonds), NewCard(Three, Hearts), NewCard(King, Clubs), NewCard(Two, Clubs), NewCard(Jack, Hearts), NewCard(Ace, Spades)}, Pair},
+		{[]Card{NewCard(Seven, Clubs), NewCard(Eight, Diamonds), NewCard(Three, Hearts), NewCard(King, Clubs), NewCard(Two, Clubs), NewCard(Jack, Hearts), NewCard(Ace, Spades)}, HighCard},
+	}
+
+	for _, tt := range tests {
+		if got := evaluateCards(tt.cards).Rank(); got != tt.expected {
+			t.Errorf("evaluateCards(%v).Rank() = %v, want %v", tt.cards, got, tt.expected)
+		}
+	}
+}
+
+func TestEvaluateCardsMatchesFindBestHand(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		deck := NewSeededDeck(rng)
+		a, _ := deck.DrawMany(7)
+		b := append(append([]Card(nil), a[:2]...), deck.Remaining()[:5]...)
+		if i%2 == 0 {
+			// Share a board so close comparisons are common
+			b = append(deck.Remaining()[:2:2], a[2:]...)
+		}
+
+		handA, handB := findBestHand(a), findBestHand(b)
+		scoreA, scoreB := evaluateCards(a), evaluateCards(b)
+
+		if scoreA.Rank() != handA.Rank() {
+			t.Fatalf("evaluateCards(%v).Rank() = %v, want %v", a, scoreA.Rank(), handA.Rank())
+		}
+
+		want := handA.Compare(handB)
+		got := 0
+		if scoreA > scoreB {
+			got = 1
+		} else if scoreA < scoreB {
+			got = -1
+		}
+		if got != want {
+			t.Fatalf("evaluateCards ordering of %v vs %v = %d, want %d", a, b, got, want)
+		}
+	}
+}
+
+func BenchmarkEvaluateCards(b *testing.B) {
+	cards := NewSeededDeck(rand.New(rand.NewSource(1))).Remaining()[:7]
+	for i := 0; i < b.N; i++ {
+		evaluateCards(cards)
+	}
+}
@@ -2,19 +2,28 @@ package goker
 
 import "fmt"
 
-// Game represents a Texas Hold'em poker game.
+// Game represents a poker game played under a set of GameRules.
+// A nil Rules field plays Texas Hold'em.
 type Game struct {
 	Deck    *Deck
 	Board   *Board
 	Players []*Player
+	Rules   GameRules
+	Runouts []*Board // Independent board runouts dealt by RunItTimes
 }
 
-// NewGame creates a new game with the specified number of players.
+// NewGame creates a new Texas Hold'em game with the specified number of players.
 func NewGame(numPlayers int) *Game {
+	return NewGameWithRules(HoldemRules{}, numPlayers)
+}
+
+// NewGameWithRules creates a new game of the given variant with the specified number of players.
+func NewGameWithRules(rules GameRules, numPlayers int) *Game {
 	g := &Game{
-		Deck:    NewDeck(),
+		Deck:    rules.NewDeck(),
 		Board:   NewBoard(),
 		Players: make([]*Player, numPlayers),
+		Rules:   rules,
 	}
 
 	for i := 0; i < numPlayers; i++ {
@@ -25,92 +34,122 @@ func NewGame(numPlayers int) *Game {
 	return g
 }
 
-// DealHoleCards deals 2 cards to each player.
+// rules returns the game's rules, defaulting to Texas Hold'em.
+func (g *Game) rules() GameRules {
+	if g.Rules == nil {
+		return HoldemRules{}
+	}
+	return g.Rules
+}
+
+// DealHoleCards deals each player the number of hole cards the rules require.
 func (g *Game) DealHoleCards() error {
+	n := g.rules().HoleCards()
 	for _, player := range g.Players {
-		cards, err := g.Deck.DrawMany(2)
+		cards, err := g.Deck.DrawMany(n)
 		if err != nil {
 			return err
 		}
-		if err := player.SetHoleCards(cards); err != nil {
+		if err := player.setHoleCards(cards, n); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// DealFlop deals the flop (3 community cards) with a burn.
+// SetHoleCards sets a player's hole cards, which must number as many as the
+// game's rules deal.
+func (g *Game) SetHoleCards(player *Player, cards []Card) error {
+	return player.setHoleCards(cards, g.rules().HoleCards())
+}
+
+// DealFlop deals the first street of the game's rules (the flop, 3 cards
+// with a burn, in flop games).
 func (g *Game) DealFlop() error {
-	if g.Board.State() != Preflop {
-		return ErrInvalidBoardState
-	}
-	if err := g.Deck.Burn(); err != nil {
-		return err
-	}
-	cards, err := g.Deck.DrawMany(3)
-	if err != nil {
-		return err
-	}
-	return g.Board.SetFlop(cards)
+	return g.dealStreet(0)
 }
 
-// DealTurn deals the turn card with a burn.
+// DealTurn deals the second street of the game's rules (the turn card with a
+// burn in flop games).
 func (g *Game) DealTurn() error {
-	if g.Board.State() != Flop {
+	return g.dealStreet(1)
+}
+
+// DealRiver deals the third street of the game's rules (the river card with
+// a burn in flop games).
+func (g *Game) DealRiver() error {
+	return g.dealStreet(2)
+}
+
+// dealStreet deals the rules' i-th street, returning ErrInvalidBoardState
+// unless the board holds exactly the streets before it.
+func (g *Game) dealStreet(i int) error {
+	streets := g.rules().Streets()
+	if i >= len(streets) {
 		return ErrInvalidBoardState
 	}
-	if err := g.Deck.Burn(); err != nil {
-		return err
+	dealt := 0
+	for _, street := range streets[:i] {
+		dealt += street.Cards
 	}
-	card, err := g.Deck.Draw()
-	if err != nil {
-		return err
+	if len(g.Board.Cards) != dealt {
+		return ErrInvalidBoardState
 	}
-	return g.Board.SetTurn(card)
+	return g.dealNextStreet(g.Board)
 }
 
-// DealRiver deals the river card with a burn.
-func (g *Game) DealRiver() error {
-	if g.Board.State() != Turn {
+// DealNextStreet advances to the next street defined by the game's rules.
+func (g *Game) DealNextStreet() error {
+	return g.dealNextStreet(g.Board)
+}
+
+// dealNextStreet deals the next street onto the given board.
+func (g *Game) dealNextStreet(board *Board) error {
+	street, ok := g.nextStreet(board)
+	if !ok {
 		return ErrInvalidBoardState
 	}
-	if err := g.Deck.Burn(); err != nil {
-		return err
+	if street.Burn {
+		if err := g.Deck.Burn(); err != nil {
+			return err
+		}
 	}
-	card, err := g.Deck.Draw()
+	cards, err := g.Deck.DrawMany(street.Cards)
 	if err != nil {
 		return err
 	}
-	return g.Board.SetRiver(card)
+	board.Cards = append(board.Cards, cards...)
+	return nil
 }
 
-// DealNextStreet advances to the next stage of the game.
-func (g *Game) DealNextStreet() error {
-	switch g.Board.State() {
-	case Preflop:
-		return g.DealFlop()
-	case Flop:
-		return g.DealTurn()
-	case Turn:
-		return g.DealRiver()
-	default:
-		return ErrInvalidBoardState
+// nextStreet returns the next street to deal given the cards already on the board.
+func (g *Game) nextStreet(board *Board) (Street, bool) {
+	dealt := 0
+	for _, street := range g.rules().Streets() {
+		if dealt == len(board.Cards) {
+			return street, true
+		}
+		dealt += street.Cards
 	}
+	return Street{}, false
 }
 
-// GetCandidateHands returns all possible 5-card hands for a player.
+// boardComplete returns true once every street has been dealt on the board.
+func (g *Game) boardComplete(board *Board) bool {
+	return len(board.Cards) == boardSize(g.rules())
+}
+
+// GetCandidateHands returns all possible 5-card hands for a player under the game's rules.
 func (g *Game) GetCandidateHands(player *Player) ([]*Hand, error) {
-	if len(g.Board.Cards) < 3 {
-		return nil, ErrInvalidBoardState
-	}
+	return g.candidateHands(player, g.Board)
+}
 
-	// Combine hole cards with board cards
-	allCards := make([]Card, 0, len(player.HoleCards)+len(g.Board.Cards))
-	allCards = append(allCards, player.HoleCards...)
-	allCards = append(allCards, g.Board.Cards...)
+func (g *Game) candidateHands(player *Player, board *Board) ([]*Hand, error) {
+	combos, err := g.rules().CandidateHands(player.HoleCards, board.Cards)
+	if err != nil {
+		return nil, err
+	}
 
-	// Generate all 5-card combinations
-	combos := CardCombinations(allCards, 5)
 	hands := make([]*Hand, 0, len(combos))
 
 	for _, combo := range combos {
@@ -126,7 +165,11 @@ func (g *Game) GetCandidateHands(player *Player) ([]*Hand, error) {
 
 // GetBestHand returns the best possible hand for a player.
 func (g *Game) GetBestHand(player *Player) (*Hand, error) {
-	hands, err := g.GetCandidateHands(player)
+	return g.bestHand(player, g.Board)
+}
+
+func (g *Game) bestHand(player *Player, board *Board) (*Hand, error) {
+	hands, err := g.candidateHands(player, board)
 	if err != nil {
 		return nil, err
 	}
@@ -144,49 +187,43 @@ func (g *Game) GetBestHand(player *Player) (*Hand, error) {
 }
 
 // GetWinners returns the winning player(s) with their best hands.
-// Returns multiple players in case of a split pot.
+// Returns multiple players in case of a split pot. When the rules split the
+// pot into shares, as hi/lo games do, it returns every player winning a
+// share, each with the hand that wins their first share; see Showdown.
 func (g *Game) GetWinners() ([]*Player, []*Hand, error) {
-	if g.Board.State() != River {
-		return nil, nil, ErrInvalidBoardState
-	}
-
-	type playerHand struct {
-		player *Player
-		hand   *Hand
-	}
+	return g.winners(g.Board, g.Players)
+}
 
-	bestHands := make([]playerHand, 0, len(g.Players))
+// GetWinnersOnBoard returns the winning player(s) and their best hands using
+// the given board instead of the game's own, e.g. one of the game's Runouts.
+func (g *Game) GetWinnersOnBoard(board *Board) ([]*Player, []*Hand, error) {
+	return g.winners(board, g.Players)
+}
 
-	for _, player := range g.Players {
-		best, err := g.GetBestHand(player)
-		if err != nil {
-			return nil, nil, err
-		}
-		bestHands = append(bestHands, playerHand{player, best})
+// winners finds the players winning a share among the given players on a board.
+func (g *Game) winners(board *Board, players []*Player) ([]*Player, []*Hand, error) {
+	shares, err := g.showdown(board, players)
+	if err != nil {
+		return nil, nil, err
 	}
+	winningPlayers, hands := shareWinners(shares)
+	return winningPlayers, hands, nil
+}
 
-	// Find the winning hand(s)
-	var winners []playerHand
-	for _, ph := range bestHands {
-		if len(winners) == 0 {
-			winners = []playerHand{ph}
-			continue
-		}
-
-		cmp := ph.hand.Compare(winners[0].hand)
-		if cmp > 0 {
-			winners = []playerHand{ph}
-		} else if cmp == 0 {
-			winners = append(winners, ph)
+// shareWinners lists the players winning any share once each, in share
+// order, with the hand that wins their first share.
+func shareWinners(shares []ShowdownShare) ([]*Player, []*Hand) {
+	var players []*Player
+	var hands []*Hand
+	seen := make(map[*Player]bool)
+	for _, share := range shares {
+		for i, player := range share.Winners {
+			if !seen[player] {
+				seen[player] = true
+				players = append(players, player)
+				hands = append(hands, share.Hands[i])
+			}
 		}
 	}
-
-	players := make([]*Player, len(winners))
-	hands := make([]*Hand, len(winners))
-	for i, w := range winners {
-		players[i] = w.player
-		hands[i] = w.hand
-	}
-
-	return players, hands, nil
+	return players, hands
 }
@@ -212,6 +212,12 @@ func (h *Hand) IsWheel() bool {
 
 // TiebreakScore returns a numeric score for breaking ties between hands of equal rank.
 func (h *Hand) TiebreakScore() int {
+	return tiebreakScore(h.Cards)
+}
+
+// tiebreakScore scores cards by frequency then by value, so hands of equal
+// rank can be ordered regardless of how many cards they contain.
+func tiebreakScore(cards []Card) int {
 	// Sort cards by frequency then by value
 	type cardWithCount struct {
 		card  Card
@@ -219,12 +225,12 @@ func (h *Hand) TiebreakScore() int {
 	}
 
 	counts := make(map[CardRank]int)
-	for _, card := range h.Cards {
+	for _, card := range cards {
 		counts[card.Rank]++
 	}
 
-	cardsWithCounts := make([]cardWithCount, len(h.Cards))
-	for i, card := range h.Cards {
+	cardsWithCounts := make([]cardWithCount, len(cards))
+	for i, card := range cards {
 		cardsWithCounts[i] = cardWithCount{card, counts[card.Rank]}
 	}
 
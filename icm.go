@@ -0,0 +1,298 @@
+package goker
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// maxICMStates caps the number of partial finishing orders exact ICM will
+// visit before returning ErrFieldTooLarge.
+const maxICMStates = 1 << 20
+
+// defaultICMTrials is the number of simulated finishes ICMMonteCarlo uses
+// when trials is not positive.
+const defaultICMTrials = 100000
+
+// ICM returns each player's prize equity under the Independent Chip Model,
+// computed exactly with the Malmuth-Harville method: a player finishes first
+// with probability proportional to their stack, and each later place is
+// decided the same way among the players left. Players with no chips are
+// treated as finishing after everyone with chips and share the payouts for
+// the places below them evenly. Exact ICM grows with the number of players
+// and paid places; ErrFieldTooLarge is returned when ICMMonteCarlo should be
+// used instead.
+func ICM(stacks []int, payouts []float64) ([]float64, error) {
+	live, err := icmLivePlayers(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+
+	places := min(len(payouts), len(live))
+	if len(live) > 64 || icmStates(len(live), places) > maxICMStates {
+		return nil, ErrFieldTooLarge
+	}
+
+	liveStacks := make([]float64, len(live))
+	for j, i := range live {
+		liveStacks[j] = float64(stacks[i])
+	}
+	probs := finishProbabilities(liveStacks, places)
+
+	equity := make([]float64, len(stacks))
+	for j, i := range live {
+		for k := 0; k < places; k++ {
+			equity[i] += probs[j][k] * payouts[k]
+		}
+	}
+	payBustedPlayers(stacks, payouts, len(live), equity)
+	return equity, nil
+}
+
+// ICMMonteCarlo approximates ICM for fields too large to solve exactly by
+// sampling finishing orders from the Malmuth-Harville model. If trials is not
+// positive a default is used; if rng is nil the default source is used.
+func ICMMonteCarlo(stacks []int, payouts []float64, trials int, rng *rand.Rand) ([]float64, error) {
+	live, err := icmLivePlayers(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+	if trials <= 0 {
+		trials = defaultICMTrials
+	}
+	expFloat := rand.ExpFloat64
+	if rng != nil {
+		expFloat = rng.ExpFloat64
+	}
+
+	// Racing exponential clocks with rates equal to the stacks finishes
+	// players in Malmuth-Harville order: the first clock to ring belongs to
+	// player i with probability stack_i / total, and so on.
+	places := min(len(payouts), len(live))
+	order := make([]int, len(live))
+	clocks := make([]float64, len(stacks))
+	totals := make([]float64, len(stacks))
+
+	for t := 0; t < trials; t++ {
+		copy(order, live)
+		for _, i := range live {
+			clocks[i] = expFloat() / float64(stacks[i])
+		}
+		sort.Slice(order, func(a, b int) bool {
+			return clocks[order[a]] < clocks[order[b]]
+		})
+		for k := 0; k < places; k++ {
+			totals[order[k]] += payouts[k]
+		}
+	}
+
+	equity := make([]float64, len(stacks))
+	for _, i := range live {
+		equity[i] = totals[i] / float64(trials)
+	}
+	payBustedPlayers(stacks, payouts, len(live), equity)
+	return equity, nil
+}
+
+// icmLivePlayers validates stacks and payouts and returns the indices of the
+// players with chips.
+func icmLivePlayers(stacks []int, payouts []float64) ([]int, error) {
+	if len(payouts) == 0 {
+		return nil, ErrInvalidPayouts
+	}
+	for _, p := range payouts {
+		if p < 0 {
+			return nil, ErrInvalidPayouts
+		}
+	}
+
+	var live []int
+	for i, s := range stacks {
+		if s < 0 {
+			return nil, ErrInvalidStacks
+		}
+		if s > 0 {
+			live = append(live, i)
+		}
+	}
+	if len(live) == 0 {
+		return nil, ErrInvalidStacks
+	}
+	return live, nil
+}
+
+// icmStates returns the number of partial finishing orders (sets of players
+// already placed) visited when solving places places among n players.
+func icmStates(n, places int) int {
+	total, c := 0, 1
+	for d := 0; d < places; d++ {
+		total += c
+		if total > maxICMStates {
+			return total
+		}
+		c = c * (n - d) / (d + 1)
+	}
+	return total
+}
+
+// finishProbabilities returns probs[i][k], the probability that player i
+// finishes in place k (0-based) for the first places places. Each set of
+// players already placed is visited once, carrying the probability that
+// those players took the places above.
+func finishProbabilities(stacks []float64, places int) [][]float64 {
+	probs := make([][]float64, len(stacks))
+	for i := range probs {
+		probs[i] = make([]float64, places)
+	}
+
+	total := 0.0
+	for _, s := range stacks {
+		total += s
+	}
+
+	level := map[uint64]float64{0: 1}
+	for k := 0; k < places; k++ {
+		next := make(map[uint64]float64, len(level)*len(stacks))
+		for placed, p := range level {
+			remaining := total
+			for i, s := range stacks {
+				if placed&(1<<i) != 0 {
+					remaining -= s
+				}
+			}
+			for i, s := range stacks {
+				if placed&(1<<i) != 0 {
+					continue
+				}
+				q := p * s / remaining
+				probs[i][k] += q
+				next[placed|1<<i] += q
+			}
+		}
+		level = next
+	}
+	return probs
+}
+
+// payBustedPlayers splits the payouts for places below the players with
+// chips evenly among the players without chips.
+func payBustedPlayers(stacks []int, payouts []float64, numLive int, equity []float64) {
+	busted := len(stacks) - numLive
+	if busted == 0 || len(payouts) <= numLive {
+		return
+	}
+
+	remaining := 0.0
+	for _, p := range payouts[numLive:min(len(payouts), len(stacks))] {
+		remaining += p
+	}
+	for i, s := range stacks {
+		if s == 0 {
+			equity[i] = remaining / float64(busted)
+		}
+	}
+}
+
+// ICMChop returns a deal that pays each player their ICM equity.
+func ICMChop(stacks []int, payouts []float64) ([]float64, error) {
+	return ICM(stacks, payouts)
+}
+
+// ChipChop returns a chip-count deal: every player with chips is guaranteed
+// the lowest payout still in play, and the rest of the remaining prize pool is
+// split in proportion to stack size.
+func ChipChop(stacks []int, payouts []float64) ([]float64, error) {
+	live, err := icmLivePlayers(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+
+	places := min(len(payouts), len(live))
+	floor := 0.0
+	if len(payouts) >= len(live) {
+		floor = payouts[len(live)-1]
+	}
+
+	pool, chips := 0.0, 0.0
+	for _, p := range payouts[:places] {
+		pool += p
+	}
+	for _, i := range live {
+		chips += float64(stacks[i])
+	}
+	pool -= floor * float64(len(live))
+
+	equity := make([]float64, len(stacks))
+	for _, i := range live {
+		equity[i] = floor + pool*float64(stacks[i])/chips
+	}
+	payBustedPlayers(stacks, payouts, len(live), equity)
+	return equity, nil
+}
+
+// ICMAllInEV returns hero's ICM equity from getting all-in against villain
+// with the given probability of winning (counting ties as half). The
+// effective stack is won or lost; stacks should already include any chips
+// committed before the all-in. Compare the result with hero's ICM equity
+// before the all-in to see whether calling or shoving is worth it.
+func ICMAllInEV(stacks []int, payouts []float64, hero, villain int, equity float64) (float64, error) {
+	if hero < 0 || hero >= len(stacks) || villain < 0 || villain >= len(stacks) || hero == villain {
+		return 0, ErrInvalidSeat
+	}
+
+	effective := min(stacks[hero], stacks[villain])
+	win := append([]int(nil), stacks...)
+	win[hero] += effective
+	win[villain] -= effective
+	lose := append([]int(nil), stacks...)
+	lose[hero] -= effective
+	lose[villain] += effective
+
+	winEquity, err := ICM(win, payouts)
+	if err != nil {
+		return 0, err
+	}
+	loseEquity, err := ICM(lose, payouts)
+	if err != nil {
+		return 0, err
+	}
+	return equity*winEquity[hero] + (1-equity)*loseEquity[hero], nil
+}
+
+// TopHeavyPayouts splits a prize pool across places so that place k (from 1)
+// is weighted by k^-steepness. A steepness of 0 pays every place the same;
+// larger values concentrate the pool at the top. Returns nil if places < 1.
+func TopHeavyPayouts(prizePool float64, places int, steepness float64) []float64 {
+	if places < 1 {
+		return nil
+	}
+
+	weights := make([]float64, places)
+	total := 0.0
+	for k := range weights {
+		weights[k] = math.Pow(float64(k+1), -steepness)
+		total += weights[k]
+	}
+
+	payouts := make([]float64, places)
+	for k, w := range weights {
+		payouts[k] = prizePool * w / total
+	}
+	return payouts
+}
+
+// PercentOfFieldPayouts pays the top percentPaid percent of entrants (rounded
+// up, at least one place) on a TopHeavyPayouts curve.
+func PercentOfFieldPayouts(prizePool float64, entrants int, percentPaid, steepness float64) []float64 {
+	return TopHeavyPayouts(prizePool, PlacesPaid(entrants, percentPaid), steepness)
+}
+
+// PlacesPaid returns the number of places paid when percentPaid percent of
+// entrants cash, rounded up and between 1 and entrants.
+func PlacesPaid(entrants int, percentPaid float64) int {
+	if entrants < 1 {
+		return 0
+	}
+	places := int(math.Ceil(float64(entrants) * percentPaid / 100))
+	return max(1, min(places, entrants))
+}
@@ -0,0 +1,257 @@
+package goker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// icmBruteForce computes Malmuth-Harville ICM by walking every finishing
+// order of the given stacks.
+func icmBruteForce(stacks []float64, payouts []float64) []float64 {
+	equity := make([]float64, len(stacks))
+	used := make([]bool, len(stacks))
+
+	var walk func(place int, p, remaining float64)
+	walk = func(place int, p, remaining float64) {
+		if place == len(payouts) || place == len(stacks) {
+			return
+		}
+		for i, s := range stacks {
+			if used[i] {
+				continue
+			}
+			q := p * s / remaining
+			equity[i] += q * payouts[place]
+			used[i] = true
+			walk(place+1, q, remaining-s)
+			used[i] = false
+		}
+	}
+
+	total := 0.0
+	for _, s := range stacks {
+		total += s
+	}
+	walk(0, 1, total)
+	return equity
+}
+
+func almostEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func sumFloats(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func TestICM(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	payouts := []float64{50, 30, 20}
+
+	equity, err := ICM(stacks, payouts)
+	if err != nil {
+		t.Fatalf("ICM() error = %v", err)
+	}
+
+	// 1st: 0.5, 2nd: 0.3*5/7 + 0.2*5/8, 3rd: the rest
+	second := 0.3*5/7 + 0.2*5/8
+	want := 0.5*50 + second*30 + (1-0.5-second)*20
+	if !almostEqual(equity[0], want, 1e-9) {
+		t.Errorf("ICM() chip leader = %f, want %f", equity[0], want)
+	}
+	if !almostEqual(sumFloats(equity), 100, 1e-9) {
+		t.Errorf("ICM() total = %f, want 100", sumFloats(equity))
+	}
+}
+
+func TestICMMatchesBruteForce(t *testing.T) {
+	stacks := []int{1200, 4500, 800, 3000, 2500, 600, 10000}
+	payouts := []float64{45, 25, 15, 10, 5}
+
+	equity, err := ICM(stacks, payouts)
+	if err != nil {
+		t.Fatalf("ICM() error = %v", err)
+	}
+
+	floats := make([]float64, len(stacks))
+	for i, s := range stacks {
+		floats[i] = float64(s)
+	}
+	want := icmBruteForce(floats, payouts)
+	for i := range want {
+		if !almostEqual(equity[i], want[i], 1e-9) {
+			t.Errorf("ICM()[%d] = %f, want %f", i, equity[i], want[i])
+		}
+	}
+}
+
+func TestICMBustedPlayers(t *testing.T) {
+	equity, err := ICM([]int{1000, 0, 3000}, []float64{50, 30, 20})
+	if err != nil {
+		t.Fatalf("ICM() error = %v", err)
+	}
+	if equity[1] != 20 {
+		t.Errorf("Busted player equity = %f, want 20", equity[1])
+	}
+	if !almostEqual(equity[0], 0.25*50+0.75*30, 1e-9) {
+		t.Errorf("Short stack equity = %f, want %f", equity[0], 0.25*50+0.75*30)
+	}
+}
+
+func TestICMInvalid(t *testing.T) {
+	if _, err := ICM([]int{100, -1}, []float64{1}); err != ErrInvalidStacks {
+		t.Errorf("ICM() with negative stack error = %v, want ErrInvalidStacks", err)
+	}
+	if _, err := ICM([]int{0, 0}, []float64{1}); err != ErrInvalidStacks {
+		t.Errorf("ICM() with no chips error = %v, want ErrInvalidStacks", err)
+	}
+	if _, err := ICM([]int{100, 100}, nil); err != ErrInvalidPayouts {
+		t.Errorf("ICM() without payouts error = %v, want ErrInvalidPayouts", err)
+	}
+	if _, err := ICM([]int{100, 100}, []float64{-1}); err != ErrInvalidPayouts {
+		t.Errorf("ICM() with negative payout error = %v, want ErrInvalidPayouts", err)
+	}
+
+	stacks := make([]int, 200)
+	for i := range stacks {
+		stacks[i] = 1000 + i
+	}
+	payouts := TopHeavyPayouts(1000, 30, 1)
+	if _, err := ICM(stacks, payouts); err != ErrFieldTooLarge {
+		t.Errorf("ICM() for 200 players error = %v, want ErrFieldTooLarge", err)
+	}
+	equity, err := ICMMonteCarlo(stacks, payouts, 2000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("ICMMonteCarlo() error = %v", err)
+	}
+	if !almostEqual(sumFloats(equity), 1000, 1e-6) {
+		t.Errorf("ICMMonteCarlo() total = %f, want 1000", sumFloats(equity))
+	}
+}
+
+func TestICMMonteCarlo(t *testing.T) {
+	stacks := []int{1200, 4500, 800, 3000, 2500, 0}
+	payouts := []float64{50, 30, 20}
+
+	exact, _ := ICM(stacks, payouts)
+	approx, err := ICMMonteCarlo(stacks, payouts, 200000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("ICMMonteCarlo() error = %v", err)
+	}
+	for i := range exact {
+		if !almostEqual(approx[i], exact[i], 0.3) {
+			t.Errorf("ICMMonteCarlo()[%d] = %f, want about %f", i, approx[i], exact[i])
+		}
+	}
+}
+
+func TestChops(t *testing.T) {
+	stacks := []int{6000, 3000, 1000}
+	payouts := []float64{500, 300, 200}
+
+	chip, err := ChipChop(stacks, payouts)
+	if err != nil {
+		t.Fatalf("ChipChop() error = %v", err)
+	}
+	// Everyone is guaranteed 200; the remaining 400 is split by chips
+	want := []float64{200 + 240, 200 + 120, 200 + 40}
+	for i := range want {
+		if !almostEqual(chip[i], want[i], 1e-9) {
+			t.Errorf("ChipChop()[%d] = %f, want %f", i, chip[i], want[i])
+		}
+	}
+
+	icm, _ := ICMChop(stacks, payouts)
+	if !almostEqual(sumFloats(icm), 1000, 1e-9) {
+		t.Errorf("ICMChop() total = %f, want 1000", sumFloats(icm))
+	}
+	// ICM favors the short stack relative to a chip chop
+	if icm[2] <= chip[2] || icm[0] >= chip[0] {
+		t.Errorf("ICMChop() = %v, ChipChop() = %v", icm, chip)
+	}
+
+	// Heads-up, ICM and a chip chop agree
+	headsUp := []int{7000, 3000}
+	icm, _ = ICMChop(headsUp, payouts)
+	chip, _ = ChipChop(headsUp, payouts)
+	for i := range icm {
+		if !almostEqual(icm[i], chip[i], 1e-9) {
+			t.Errorf("Heads-up ICMChop() = %v, ChipChop() = %v", icm, chip)
+		}
+	}
+}
+
+func TestICMAllInEV(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	payouts := []float64{50, 30, 20}
+
+	before, _ := ICM(stacks, payouts)
+
+	// A coin flip loses equity under ICM
+	ev, err := ICMAllInEV(stacks, payouts, 2, 1, 0.5)
+	if err != nil {
+		t.Fatalf("ICMAllInEV() error = %v", err)
+	}
+	if ev >= before[2] {
+		t.Errorf("ICMAllInEV() for a coin flip = %f, should be below %f", ev, before[2])
+	}
+
+	// A lock wins the doubled-up equity
+	ev, _ = ICMAllInEV(stacks, payouts, 2, 1, 1)
+	doubled, _ := ICM([]int{5000, 1000, 4000}, payouts)
+	if !almostEqual(ev, doubled[2], 1e-9) {
+		t.Errorf("ICMAllInEV() with a lock = %f, want %f", ev, doubled[2])
+	}
+
+	if _, err := ICMAllInEV(stacks, payouts, 1, 1, 0.5); err != ErrInvalidSeat {
+		t.Errorf("ICMAllInEV() against self error = %v, want ErrInvalidSeat", err)
+	}
+}
+
+func TestPayouts(t *testing.T) {
+	flat := TopHeavyPayouts(900, 3, 0)
+	for _, p := range flat {
+		if !almostEqual(p, 300, 1e-9) {
+			t.Errorf("Flat payouts = %v, want 300 each", flat)
+		}
+	}
+
+	payouts := PercentOfFieldPayouts(10000, 100, 15, 1.2)
+	if len(payouts) != 15 {
+		t.Errorf("PercentOfFieldPayouts() paid %d places, want 15", len(payouts))
+	}
+	if !almostEqual(sumFloats(payouts), 10000, 1e-6) {
+		t.Errorf("PercentOfFieldPayouts() total = %f, want 10000", sumFloats(payouts))
+	}
+	for i := 1; i < len(payouts); i++ {
+		if payouts[i] > payouts[i-1] {
+			t.Errorf("Payouts increase at place %d: %v", i+1, payouts)
+		}
+	}
+
+	if TopHeavyPayouts(100, 0, 1) != nil {
+		t.Error("TopHeavyPayouts() with no places should be nil")
+	}
+
+	tests := []struct {
+		entrants int
+		percent  float64
+		expected int
+	}{
+		{100, 15, 15},
+		{9, 15, 2},
+		{9, 0, 1},
+		{10, 200, 10},
+		{0, 15, 0},
+	}
+	for _, tt := range tests {
+		if got := PlacesPaid(tt.entrants, tt.percent); got != tt.expected {
+			t.Errorf("PlacesPaid(%d, %v) = %d, want %d", tt.entrants, tt.percent, got, tt.expected)
+		}
+	}
+}
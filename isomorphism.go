@@ -0,0 +1,284 @@
+package goker
+
+import (
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// Suit isomorphism: relabelling the suits of every card in a spot doesn't
+// change how any hand plays, so spots that differ only by a suit permutation
+// can share one computation. Cards are handled here as 52-bit sets, one
+// 13-bit block of ranks per suit, which makes relabelling suits a matter of
+// moving blocks.
+
+// cardSet is a set of cards with card suit*13+rank-2 in bit position order.
+type cardSet uint64
+
+// rankBits masks one suit's block of a cardSet.
+const rankBits = 1<<13 - 1
+
+// cardBit returns the set holding just c.
+func cardBit(c Card) cardSet {
+	return 1 << (int(c.Suit)*13 + int(c.Rank-Two))
+}
+
+// newCardSet returns the set of cards, and false if any card repeats.
+func newCardSet(cards []Card) (cardSet, bool) {
+	var s cardSet
+	for _, c := range cards {
+		b := cardBit(c)
+		if s&b != 0 {
+			return s, false
+		}
+		s |= b
+	}
+	return s, true
+}
+
+// cards returns the cards in the set, clubs first and low ranks first.
+func (s cardSet) cards() []Card {
+	cards := make([]Card, 0, bits.OnesCount64(uint64(s)))
+	for s != 0 {
+		i := bits.TrailingZeros64(uint64(s))
+		cards = append(cards, NewCard(CardRank(i%13)+Two, CardSuit(i/13)))
+		s &= s - 1
+	}
+	return cards
+}
+
+// permute relabels the suits of the set, moving suit s to perm[s].
+func (s cardSet) permute(perm [4]CardSuit) cardSet {
+	var out cardSet
+	for suit, to := range perm {
+		out |= (s >> (13 * suit) & rankBits) << (13 * int(to))
+	}
+	return out
+}
+
+// allSuitPermutations caches suitPermutations.
+var allSuitPermutations = suitPermutations()
+
+// canonicalSets relabels the suits of a sequence of card sets to the
+// permutation that makes it lexicographically smallest, and returns the
+// result with the number of distinct relabellings of the input.
+func canonicalSets(sets []cardSet) (canonical []cardSet, images int) {
+	n := len(sets)
+	mapped := make([]cardSet, n*len(allSuitPermutations))
+
+	for p, perm := range allSuitPermutations {
+		image := mapped[images*n : images*n+n]
+		for i, s := range sets {
+			image[i] = s.permute(perm)
+		}
+		if p == 0 || lessSets(image, canonical) {
+			canonical = append(canonical[:0], image...)
+		}
+
+		seen := false
+		for j := 0; j < images && !seen; j++ {
+			seen = !lessSets(image, mapped[j*n:j*n+n]) && !lessSets(mapped[j*n:j*n+n], image)
+		}
+		if !seen {
+			images++
+		}
+	}
+	return canonical, images
+}
+
+// lessSets compares two equal-length sequences of card sets lexicographically.
+func lessSets(a, b []cardSet) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Canonicalize relabels the suits of a spot to a fixed representative of
+// every spot that differs from it only by suits. Each player's hole cards
+// and the board are treated as sets, so two spots canonicalize to the same
+// cards exactly when a suit permutation maps each player's hole cards and
+// the board onto the other's. The returned cards are sorted by rank, high
+// first, then suit.
+func Canonicalize(holeCards [][]Card, board []Card) ([][]Card, []Card) {
+	sets := make([]cardSet, 0, len(holeCards)+1)
+	for _, hole := range holeCards {
+		s, _ := newCardSet(hole)
+		sets = append(sets, s)
+	}
+	s, _ := newCardSet(board)
+	sets = append(sets, s)
+
+	canonical, _ := canonicalSets(sets)
+	hole := make([][]Card, len(holeCards))
+	for i := range hole {
+		hole[i] = sortedByRank(canonical[i].cards())
+	}
+	return hole, sortedByRank(canonical[len(holeCards)].cards())
+}
+
+// sortedByRank sorts cards high rank first, breaking ties by suit.
+func sortedByRank(cards []Card) []Card {
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Rank != cards[j].Rank {
+			return cards[i].Rank > cards[j].Rank
+		}
+		return cards[i].Suit < cards[j].Suit
+	})
+	return cards
+}
+
+// CanonicalIndex returns a number identifying a player's spot up to suits:
+// two sets of hole cards and boards share an index exactly when one is a
+// suit relabelling of the other. Indexes are sparse and support up to ten
+// cards in total; see CanonicalBoardIndex for dense board indexes.
+func CanonicalIndex(holeCards, board []Card) uint64 {
+	hole, _ := newCardSet(holeCards)
+	b, _ := newCardSet(board)
+	canonical, _ := canonicalSets([]cardSet{hole, b})
+
+	// The hole card count, then each card's position plus one in 6 bits
+	index := uint64(bits.OnesCount64(uint64(canonical[0])))
+	for _, s := range canonical {
+		for s != 0 {
+			index = index<<6 | uint64(bits.TrailingZeros64(uint64(s))+1)
+			s &= s - 1
+		}
+	}
+	return index
+}
+
+// CanonicalBoard is a board standing for every board that differs from it
+// only by suits.
+type CanonicalBoard struct {
+	Cards  []Card
+	Combos int // Number of boards it stands for
+}
+
+var canonicalBoards struct {
+	once  [6]sync.Once
+	lists [6][]CanonicalBoard
+	index [6]map[cardSet]int
+}
+
+// CanonicalBoards returns one board for each class of boards of the given
+// size (3, 4 or 5 cards) that are equal up to suits, with the number of
+// boards in the class: 1,755 flops, 16,432 turns and 134,459 rivers. The
+// lists are computed once and shared, so they must not be modified.
+func CanonicalBoards(size int) ([]CanonicalBoard, error) {
+	if size < 3 || size > 5 {
+		return nil, ErrInvalidBoardSize
+	}
+	canonicalBoards.once[size].Do(func() {
+		var list []CanonicalBoard
+		index := make(map[cardSet]int)
+		forEachCardSet(fullCardSet, size, func(s cardSet) {
+			if images := orbitSize(s, allSuitPermutations); images > 0 {
+				index[s] = len(list)
+				list = append(list, CanonicalBoard{Cards: sortedByRank(s.cards()), Combos: images})
+			}
+		})
+		canonicalBoards.lists[size] = list
+		canonicalBoards.index[size] = index
+	})
+	return canonicalBoards.lists[size], nil
+}
+
+// CanonicalBoardIndex returns the position in CanonicalBoards of the class a
+// flop, turn or river belongs to.
+func CanonicalBoardIndex(board []Card) (int, error) {
+	s, ok := newCardSet(board)
+	if !ok {
+		return 0, ErrDuplicateCards
+	}
+	if _, err := CanonicalBoards(len(board)); err != nil {
+		return 0, err
+	}
+	canonical, _ := canonicalSets([]cardSet{s})
+	return canonicalBoards.index[len(board)][canonical[0]], nil
+}
+
+// fullCardSet holds all 52 cards.
+const fullCardSet cardSet = 1<<52 - 1
+
+// forEachCardSet calls fn with every n-card subset of a set.
+func forEachCardSet(from cardSet, n int, fn func(cardSet)) {
+	var walk func(rest, chosen cardSet, n int)
+	walk = func(rest, chosen cardSet, n int) {
+		if n == 0 {
+			fn(chosen)
+			return
+		}
+		for rest != 0 && bits.OnesCount64(uint64(rest)) >= n {
+			low := rest & -rest
+			rest &^= low
+			walk(rest, chosen|low, n-1)
+		}
+	}
+	walk(from, 0, n)
+}
+
+// suitSymmetries returns the suit permutations that map every player's hole
+// cards and each other set of cards, such as the board, onto themselves, so
+// runouts related by one of them play out identically. The identity is
+// always included.
+func suitSymmetries(holeCards [][]Card, fixed ...[]Card) [][4]CardSuit {
+	sets := make([]cardSet, 0, len(holeCards)+len(fixed))
+	for _, cards := range append(append([][]Card(nil), holeCards...), fixed...) {
+		s, _ := newCardSet(cards)
+		sets = append(sets, s)
+	}
+
+	var symmetries [][4]CardSuit
+	for _, perm := range allSuitPermutations {
+		fixed := true
+		for _, s := range sets {
+			if s.permute(perm) != s {
+				fixed = false
+				break
+			}
+		}
+		if fixed {
+			symmetries = append(symmetries, perm)
+		}
+	}
+	return symmetries
+}
+
+// runoutWeight returns how many runouts the runout stands for under a group
+// of suit symmetries, or 0 if another runout stands for it instead.
+func runoutWeight(runout []Card, symmetries [][4]CardSuit) int {
+	if len(symmetries) == 1 {
+		return 1
+	}
+	s, _ := newCardSet(runout)
+	return orbitSize(s, symmetries)
+}
+
+// orbitSize returns the number of distinct sets a group of suit permutations
+// maps a set to, or 0 if one of them maps it to a smaller set, so that each
+// orbit is counted once, at its smallest set.
+func orbitSize(s cardSet, group [][4]CardSuit) int {
+	var images [24]cardSet
+	n := 0
+	for _, perm := range group {
+		image := s.permute(perm)
+		if image < s {
+			return 0
+		}
+		seen := false
+		for _, other := range images[:n] {
+			if other == image {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			images[n] = image
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,204 @@
+package goker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCanonicalBoards(t *testing.T) {
+	tests := []struct {
+		size    int
+		classes int
+		boards  int
+	}{
+		{3, 1755, 22100},
+		{4, 16432, 270725},
+		{5, 134459, 2598960},
+	}
+
+	for _, tt := range tests {
+		boards, err := CanonicalBoards(tt.size)
+		if err != nil {
+			t.Fatalf("CanonicalBoards(%d) error: %v", tt.size, err)
+		}
+		if len(boards) != tt.classes {
+			t.Errorf("CanonicalBoards(%d) returned %d boards, want %d", tt.size, len(boards), tt.classes)
+		}
+		total := 0
+		for _, b := range boards {
+			total += b.Combos
+		}
+		if total != tt.boards {
+			t.Errorf("CanonicalBoards(%d) combos sum to %d, want %d", tt.size, total, tt.boards)
+		}
+	}
+
+	for _, size := range []int{0, 2, 6} {
+		if _, err := CanonicalBoards(size); err != ErrInvalidBoardSize {
+			t.Errorf("CanonicalBoards(%d) error = %v, want ErrInvalidBoardSize", size, err)
+		}
+	}
+}
+
+func TestCanonicalBoardIndex(t *testing.T) {
+	flops, _ := CanonicalBoards(3)
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		flop, _ := NewSeededDeck(rng).DrawMany(3)
+		index, err := CanonicalBoardIndex(flop)
+		if err != nil {
+			t.Fatalf("CanonicalBoardIndex(%v) error: %v", flop, err)
+		}
+
+		// Relabelling the suits keeps the index, and the class's board is a
+		// relabelling of the flop
+		perm := allSuitPermutations[rng.Intn(len(allSuitPermutations))]
+		relabelled := make([]Card, len(flop))
+		for j, c := range flop {
+			relabelled[j] = NewCard(c.Rank, perm[c.Suit])
+		}
+		if got, _ := CanonicalBoardIndex(relabelled); got != index {
+			t.Fatalf("CanonicalBoardIndex(%v) = %d, want %d as for %v", relabelled, got, index, flop)
+		}
+		if got := CanonicalIndex(nil, flops[index].Cards); got != CanonicalIndex(nil, flop) {
+			t.Fatalf("CanonicalBoards(3)[%d] = %v isn't isomorphic to %v", index, flops[index].Cards, flop)
+		}
+	}
+
+	if _, err := CanonicalBoardIndex([]Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(Two, Clubs)}); err != ErrDuplicateCards {
+		t.Errorf("CanonicalBoardIndex with duplicates error = %v, want ErrDuplicateCards", err)
+	}
+	if _, err := CanonicalBoardIndex([]Card{NewCard(Ace, Spades)}); err != ErrInvalidBoardSize {
+		t.Errorf("CanonicalBoardIndex with one card error = %v, want ErrInvalidBoardSize", err)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      [][]Card
+		boardA    []Card
+		boardB    []Card
+		isomorphs bool
+	}{
+		{
+			"suits swapped",
+			[][]Card{{NewCard(Ace, Spades), NewCard(King, Spades)}, {NewCard(Queen, Hearts), NewCard(Queen, Diamonds)}},
+			[][]Card{{NewCard(Ace, Clubs), NewCard(King, Clubs)}, {NewCard(Queen, Spades), NewCard(Queen, Hearts)}},
+			[]Card{NewCard(Two, Spades), NewCard(Seven, Clubs), NewCard(Nine, Hearts)},
+			[]Card{NewCard(Two, Clubs), NewCard(Seven, Diamonds), NewCard(Nine, Spades)},
+			true,
+		},
+		{
+			"card order ignored",
+			[][]Card{{NewCard(Ace, Spades), NewCard(King, Hearts)}},
+			[][]Card{{NewCard(King, Clubs), NewCard(Ace, Diamonds)}},
+			[]Card{NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Clubs)},
+			[]Card{NewCard(Three, Clubs), NewCard(Two, Diamonds), NewCard(Four, Hearts)},
+			true,
+		},
+		{
+			"flush draw differs",
+			[][]Card{{NewCard(Ace, Spades), NewCard(King, Spades)}},
+			[][]Card{{NewCard(Ace, Spades), NewCard(King, Spades)}},
+			[]Card{NewCard(Two, Spades), NewCard(Seven, Spades), NewCard(Nine, Hearts)},
+			[]Card{NewCard(Two, Spades), NewCard(Seven, Hearts), NewCard(Nine, Hearts)},
+			false,
+		},
+		{
+			"players swapped",
+			[][]Card{{NewCard(Ace, Spades), NewCard(Ace, Hearts)}, {NewCard(King, Spades), NewCard(King, Clubs)}},
+			[][]Card{{NewCard(Ace, Spades), NewCard(Ace, Clubs)}, {NewCard(King, Spades), NewCard(King, Hearts)}},
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holeA, boardA := Canonicalize(tt.a, tt.boardA)
+			holeB, boardB := Canonicalize(tt.b, tt.boardB)
+			same := cardsString(boardA) == cardsString(boardB)
+			for i := range holeA {
+				same = same && cardsString(holeA[i]) == cardsString(holeB[i])
+			}
+			if same != tt.isomorphs {
+				t.Errorf("Canonicalize gave %v %v and %v %v, isomorphic = %v, want %v", holeA, boardA, holeB, boardB, same, tt.isomorphs)
+			}
+
+			if len(tt.a) == 1 {
+				indexA, indexB := CanonicalIndex(tt.a[0], tt.boardA), CanonicalIndex(tt.b[0], tt.boardB)
+				if (indexA == indexB) != tt.isomorphs {
+					t.Errorf("CanonicalIndex = %d and %d, want equal = %v", indexA, indexB, tt.isomorphs)
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalIndexPreflop(t *testing.T) {
+	indexes := make(map[uint64]bool)
+	for _, h := range AllStartingHands() {
+		for _, cards := range h.Cards() {
+			indexes[CanonicalIndex(cards, nil)] = true
+		}
+	}
+	if len(indexes) != numStartingHands {
+		t.Errorf("got %d canonical preflop hands, want %d", len(indexes), numStartingHands)
+	}
+}
+
+func TestCalculateExactSuitSymmetry(t *testing.T) {
+	// Hearts and diamonds can be swapped without changing any hand
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(Ace, Diamonds)},
+		{NewCard(King, Hearts), NewCard(King, Diamonds)},
+	}
+	board := []Card{NewCard(Queen, Clubs), NewCard(Jack, Spades), NewCard(Two, Clubs)}
+	if n := len(suitSymmetries(holeCards, board)); n != 2 {
+		t.Fatalf("suitSymmetries = %d permutations, want 2", n)
+	}
+
+	ec := NewEquityCalculator(2)
+	results, err := ec.CalculateExact(holeCards, board, 1000)
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
+	if results == nil {
+		t.Fatal("CalculateExact returned nil")
+	}
+
+	// Enumerate every runout directly
+	used, _ := newCardSet(append(append(append([]Card(nil), holeCards[0]...), holeCards[1]...), board...))
+	wins, ties, total := 0, 0, 0
+	forEachCardSet(fullCardSet&^used, 2, func(runout cardSet) {
+		full := append(append([]Card(nil), board...), runout.cards()...)
+		a := evaluateCards(append(append([]Card(nil), holeCards[0]...), full...))
+		b := evaluateCards(append(append([]Card(nil), holeCards[1]...), full...))
+		switch {
+		case a > b:
+			wins++
+		case a == b:
+			ties++
+		}
+		total++
+	})
+
+	if results[0].Wins != wins || results[0].Ties != ties || results[0].Total != total {
+		t.Errorf("CalculateExact = %d wins, %d ties of %d, want %d, %d of %d",
+			results[0].Wins, results[0].Ties, results[0].Total, wins, ties, total)
+	}
+	if results[1].Wins != total-wins-ties {
+		t.Errorf("CalculateExact player 2 wins = %d, want %d", results[1].Wins, total-wins-ties)
+	}
+}
+
+func cardsString(cards []Card) string {
+	s := ""
+	for _, c := range cards {
+		s += c.String()
+	}
+	return s
+}
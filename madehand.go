@@ -0,0 +1,239 @@
+package goker
+
+// MadeHandClass describes a made hand by how the hole cards combine with the
+// board, which HandRank alone doesn't say: a pair may be an overpair or the
+// board's own pair. Classes are ordered roughly from weakest to strongest.
+type MadeHandClass int
+
+const (
+	NoPair           MadeHandClass = iota + 1 // High card only
+	PlayingTheBoard                           // The board makes the hand; hole cards are at most kickers
+	Underpair                                 // Pocket pair below every board card
+	BottomPair                                // A hole card pairs the lowest board rank
+	MiddlePair                                // A hole card pairs a middle board rank, or a pocket pair between board ranks
+	TopPair                                   // A hole card pairs the highest board rank
+	Overpair                                  // Pocket pair above every board card
+	TwoPairOneCard                            // One hole card pairs the board, which is paired itself
+	TwoPairBothCards                          // Each hole card pairs a board card
+	Trips                                     // A hole card matches a board pair
+	Set                                       // A pocket pair matches a board card
+	StraightOrBetter                          // A straight or better using a hole card
+)
+
+func (c MadeHandClass) String() string {
+	switch c {
+	case NoPair:
+		return "No Pair"
+	case PlayingTheBoard:
+		return "Playing the Board"
+	case Underpair:
+		return "Underpair"
+	case BottomPair:
+		return "Bottom Pair"
+	case MiddlePair:
+		return "Middle Pair"
+	case TopPair:
+		return "Top Pair"
+	case Overpair:
+		return "Overpair"
+	case TwoPairOneCard:
+		return "Two Pair (One Card)"
+	case TwoPairBothCards:
+		return "Two Pair (Both Cards)"
+	case Trips:
+		return "Trips"
+	case Set:
+		return "Set"
+	case StraightOrBetter:
+		return "Straight or Better"
+	default:
+		return "Unknown"
+	}
+}
+
+// KickerQuality rates a hole card kicker against the best kicker possible
+// on the board.
+type KickerQuality int
+
+const (
+	NoKicker   KickerQuality = iota // The class doesn't depend on a kicker
+	WeakKicker                      // Below the four best kickers possible
+	GoodKicker                      // The second to fourth best kicker possible
+	TopKicker                       // The best kicker possible
+)
+
+func (k KickerQuality) String() string {
+	switch k {
+	case NoKicker:
+		return "No Kicker"
+	case WeakKicker:
+		return "Weak Kicker"
+	case GoodKicker:
+		return "Good Kicker"
+	case TopKicker:
+		return "Top Kicker"
+	default:
+		return "Unknown"
+	}
+}
+
+// MadeHand is a player's best hand classified against the board.
+type MadeHand struct {
+	Hand      *Hand
+	Class     MadeHandClass
+	HoleCards []Card // The hole cards that are part of Hand, none when the board plays
+
+	// Kicker rates the player's best other hole card for TopPair and Trips,
+	// where it decides most showdowns against the same class.
+	Kicker KickerQuality
+}
+
+// ClassifyHand classifies a player's best hand, as GetBestHand returns it,
+// against the board. The board must have at least a flop.
+func (g *Game) ClassifyHand(player *Player) (*MadeHand, error) {
+	if len(g.Board.Cards) < 3 {
+		return nil, ErrInvalidBoardState
+	}
+	best, err := g.GetBestHand(player)
+	if err != nil {
+		return nil, err
+	}
+	return classifyMadeHand(best, player.HoleCards, g.Board.Cards), nil
+}
+
+// ClassifyMadeHand classifies the best Texas Hold'em hand made from hole
+// cards and a flop, turn or river against the board, without a Game.
+func ClassifyMadeHand(holeCards []Card, board *Board) (*MadeHand, error) {
+	if board == nil || len(board.Cards) < 3 {
+		return nil, ErrInvalidBoardSize
+	}
+	if err := validateDeal([][]Card{holeCards}, board.Cards, nil); err != nil {
+		return nil, err
+	}
+	best := findBestHand(append(append([]Card(nil), holeCards...), board.Cards...))
+	return classifyMadeHand(best, holeCards, board.Cards), nil
+}
+
+// classifyMadeHand classifies a best hand by the hole cards it contains.
+// Hole cards only count when the hand beats the board's own five cards: a
+// hand that ties the board may hold hole cards that merely match board
+// cards, as 5♠ does in 9♣8♦7♥6♣5♦.
+func classifyMadeHand(best *Hand, holeCards, board []Card) *MadeHand {
+	made := &MadeHand{Hand: best}
+	boardPlays := len(board) == handSize && evaluateCards(best.Cards) == evaluateCards(board)
+	var counts, fromHole [Ace + 1]int
+	for _, c := range best.Cards {
+		counts[c.Rank]++
+		if boardPlays {
+			continue
+		}
+		for _, h := range holeCards {
+			if c == h {
+				made.HoleCards = append(made.HoleCards, c)
+				fromHole[c.Rank]++
+			}
+		}
+	}
+
+	// The ranks making up the hand's pairs or trips, highest first
+	var groups []CardRank
+	for r := Ace; r >= Two; r-- {
+		if counts[r] >= 2 {
+			groups = append(groups, r)
+		}
+	}
+	boardRanks := rankMask(board)
+
+	switch rank := best.Rank(); {
+	case boardPlays && rank > HighCard:
+		made.Class = PlayingTheBoard
+	case rank >= Straight:
+		made.Class = PlayingTheBoard
+		if len(made.HoleCards) > 0 {
+			made.Class = StraightOrBetter
+		}
+	case rank == ThreeOfAKind:
+		made.Class = [...]MadeHandClass{PlayingTheBoard, Trips, Set}[fromHole[groups[0]]]
+		if made.Class == Trips {
+			made.Kicker = kickerQuality(holeCards, boardRanks, groups[0])
+		}
+	case rank == TwoPair:
+		high, low := fromHole[groups[0]], fromHole[groups[1]]
+		switch {
+		case high == 2:
+			made.Class = pocketPairClass(groups[0], boardRanks)
+		case low == 2:
+			made.Class = pocketPairClass(groups[1], boardRanks)
+		case high == 1 && low == 1:
+			made.Class = TwoPairBothCards
+		case high+low == 1:
+			made.Class = TwoPairOneCard
+		default:
+			made.Class = PlayingTheBoard
+		}
+	case rank == Pair:
+		pair := groups[0]
+		switch fromHole[pair] {
+		case 2:
+			made.Class = pocketPairClass(pair, boardRanks)
+		case 1:
+			made.Class = MiddlePair
+			ranks := topRanks(boardRanks, 13)
+			switch pair {
+			case ranks[0]:
+				made.Class = TopPair
+				made.Kicker = kickerQuality(holeCards, boardRanks, pair)
+			case ranks[len(ranks)-1]:
+				made.Class = BottomPair
+			}
+		default:
+			made.Class = PlayingTheBoard
+		}
+	default:
+		made.Class = NoPair
+	}
+	return made
+}
+
+// pocketPairClass places a pocket pair against the board's ranks.
+func pocketPairClass(pair CardRank, boardRanks int) MadeHandClass {
+	ranks := topRanks(boardRanks, 13)
+	switch {
+	case pair > ranks[0]:
+		return Overpair
+	case pair < ranks[len(ranks)-1]:
+		return Underpair
+	default:
+		return MiddlePair
+	}
+}
+
+// kickerQuality rates the player's highest hole card other than the made
+// rank by its place among the ranks that could be a kicker: those not on the
+// board and not the made rank.
+func kickerQuality(holeCards []Card, boardRanks int, made CardRank) KickerQuality {
+	kicker := CardRank(0)
+	for _, c := range holeCards {
+		if c.Rank != made && c.Rank > kicker {
+			kicker = c.Rank
+		}
+	}
+	if kicker == 0 {
+		return NoKicker
+	}
+
+	better := 0
+	for r := Ace; r > kicker; r-- {
+		if boardRanks&(1<<r) == 0 && r != made {
+			better++
+		}
+	}
+	switch {
+	case better == 0:
+		return TopKicker
+	case better <= 3:
+		return GoodKicker
+	default:
+		return WeakKicker
+	}
+}
@@ -0,0 +1,86 @@
+package goker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyMadeHand(t *testing.T) {
+	c := NewCard
+	tests := []struct {
+		name   string
+		hole   []Card
+		board  []Card
+		class  MadeHandClass
+		kicker KickerQuality
+	}{
+		{"no pair", []Card{c(Ace, Spades), c(King, Hearts)}, []Card{c(Nine, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, NoPair, NoKicker},
+		{"overpair", []Card{c(Queen, Spades), c(Queen, Hearts)}, []Card{c(Nine, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, Overpair, NoKicker},
+		{"underpair", []Card{c(Four, Spades), c(Four, Hearts)}, []Card{c(Nine, Clubs), c(Seven, Diamonds), c(Five, Hearts)}, Underpair, NoKicker},
+		{"pocket pair between", []Card{c(Eight, Spades), c(Eight, Hearts)}, []Card{c(Nine, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, MiddlePair, NoKicker},
+		{"top pair top kicker", []Card{c(Ace, Spades), c(King, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, TopPair, TopKicker},
+		{"top pair good kicker", []Card{c(Queen, Spades), c(Ace, Hearts)}, []Card{c(Ace, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, TopPair, GoodKicker},
+		{"top pair weak kicker", []Card{c(King, Spades), c(Six, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, TopPair, WeakKicker},
+		{"middle pair", []Card{c(Ace, Spades), c(Seven, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, MiddlePair, NoKicker},
+		{"bottom pair", []Card{c(Ace, Spades), c(Two, Spades)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, BottomPair, NoKicker},
+		{"board pair", []Card{c(Ace, Spades), c(Queen, Hearts)}, []Card{c(Seven, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, PlayingTheBoard, NoKicker},
+		{"overpair on a paired board", []Card{c(Queen, Spades), c(Queen, Hearts)}, []Card{c(Seven, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, Overpair, NoKicker},
+		{"two pair both cards", []Card{c(King, Spades), c(Seven, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, TwoPairBothCards, NoKicker},
+		{"two pair one card", []Card{c(King, Spades), c(Four, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Seven, Hearts)}, TwoPairOneCard, NoKicker},
+		{"set", []Card{c(Seven, Spades), c(Seven, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Two, Hearts)}, Set, NoKicker},
+		{"trips", []Card{c(Ace, Spades), c(Seven, Hearts)}, []Card{c(King, Clubs), c(Seven, Diamonds), c(Seven, Clubs)}, Trips, TopKicker},
+		{"straight", []Card{c(Eight, Spades), c(Six, Hearts)}, []Card{c(Nine, Clubs), c(Seven, Diamonds), c(Five, Hearts)}, StraightOrBetter, NoKicker},
+		{"board straight", []Card{c(Two, Spades), c(Two, Hearts)}, []Card{c(Nine, Clubs), c(Eight, Diamonds), c(Seven, Hearts), c(Six, Clubs), c(Five, Spades)}, PlayingTheBoard, NoKicker},
+		{"board straight with a matching hole card", []Card{c(Five, Spades), c(Two, Hearts)}, []Card{c(Nine, Clubs), c(Eight, Diamonds), c(Seven, Hearts), c(Six, Clubs), c(Five, Diamonds)}, PlayingTheBoard, NoKicker},
+		{"straight above the board's", []Card{c(Ten, Spades), c(Two, Hearts)}, []Card{c(Nine, Clubs), c(Eight, Diamonds), c(Seven, Hearts), c(Six, Clubs), c(Five, Diamonds)}, StraightOrBetter, NoKicker},
+		{"board two pair", []Card{c(Three, Spades), c(Four, Hearts)}, []Card{c(Ace, Clubs), c(Ace, Diamonds), c(King, Hearts), c(King, Clubs), c(Queen, Spades)}, PlayingTheBoard, NoKicker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			made, err := ClassifyMadeHand(tt.hole, &Board{Cards: tt.board})
+			if err != nil {
+				t.Fatalf("ClassifyMadeHand error: %v", err)
+			}
+			if made.Class != tt.class || made.Kicker != tt.kicker {
+				t.Errorf("ClassifyMadeHand = %v, %v with %v, want %v, %v", made.Class, made.Kicker, made.Hand, tt.class, tt.kicker)
+			}
+			if tt.class == PlayingTheBoard && len(tt.board) == handSize && len(made.HoleCards) != 0 {
+				t.Errorf("ClassifyMadeHand credited hole cards %v when the board plays", made.HoleCards)
+			}
+		})
+	}
+}
+
+func TestGameClassifyHand(t *testing.T) {
+	game := NewGame(2)
+	game.Players[0].HoleCards = []Card{NewCard(King, Spades), NewCard(Seven, Hearts)}
+	game.Board.Cards = []Card{NewCard(King, Clubs), NewCard(Seven, Diamonds), NewCard(Two, Hearts), NewCard(Nine, Spades)}
+
+	made, err := game.ClassifyHand(game.Players[0])
+	if err != nil {
+		t.Fatalf("ClassifyHand error: %v", err)
+	}
+	if made.Class != TwoPairBothCards {
+		t.Errorf("ClassifyHand = %v, want %v", made.Class, TwoPairBothCards)
+	}
+	if len(made.HoleCards) != 2 {
+		t.Errorf("ClassifyHand used hole cards %v, want both", made.HoleCards)
+	}
+
+	game.Board.Cards = nil
+	if _, err := game.ClassifyHand(game.Players[0]); err != ErrInvalidBoardState {
+		t.Errorf("ClassifyHand preflop error = %v, want ErrInvalidBoardState", err)
+	}
+}
+
+func TestClassifyMadeHandInvalid(t *testing.T) {
+	hole := []Card{NewCard(Ace, Spades), NewCard(King, Spades)}
+	if _, err := ClassifyMadeHand(hole, NewBoard()); !errors.Is(err, ErrInvalidBoardSize) {
+		t.Errorf("ClassifyMadeHand preflop error = %v, want ErrInvalidBoardSize", err)
+	}
+	board := &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(Two, Clubs), NewCard(Three, Clubs)}}
+	if _, err := ClassifyMadeHand(hole, board); !errors.Is(err, ErrDuplicateCards) {
+		t.Errorf("ClassifyMadeHand with duplicates error = %v, want ErrDuplicateCards", err)
+	}
+}
@@ -0,0 +1,230 @@
+package goker
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// SeatMove records a player moved between tables by balancing or a table break.
+type SeatMove struct {
+	Player   *Player
+	From     *Table
+	FromSeat int
+	To       *Table
+	ToSeat   int
+}
+
+// MultiTableTournament assigns tournament players to tables and keeps the
+// tables balanced as players bust. All random choices (initial seating,
+// buttons and the seats given to moved players) come from a seeded source, so
+// the same seed and sequence of busts always produce the same seating.
+type MultiTableTournament struct {
+	Tables        []*Table
+	SeatsPerTable int
+	Tournament    *Tournament // Optional; supplies the blinds for new tables
+
+	rng *rand.Rand
+}
+
+// NewMultiTableTournament seats players with the given starting stack at the
+// fewest tables of seatsPerTable seats that hold them, with table sizes
+// differing by at most one. tournament may be nil. Returns ErrInvalidStacks
+// unless stack is positive.
+func NewMultiTableTournament(players []*Player, stack, seatsPerTable int, tournament *Tournament, seed int64) (*MultiTableTournament, error) {
+	if seatsPerTable < 2 {
+		return nil, ErrInvalidSeat
+	}
+	if len(players) < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+	if stack <= 0 {
+		return nil, ErrInvalidStacks
+	}
+
+	m := &MultiTableTournament{
+		SeatsPerTable: seatsPerTable,
+		Tournament:    tournament,
+		rng:           rand.New(rand.NewSource(seed)),
+	}
+
+	numTables := (len(players) + seatsPerTable - 1) / seatsPerTable
+	for i := 0; i < numTables; i++ {
+		m.Tables = append(m.Tables, m.newTable())
+	}
+
+	order := m.rng.Perm(len(players))
+	for i, idx := range order {
+		table := m.Tables[i%numTables]
+		if err := table.Sit(m.randomEmptySeat(table), players[idx], stack); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, table := range m.Tables {
+		occupied := table.activeSeats(0)
+		table.Button = occupied[m.rng.Intn(len(occupied))]
+	}
+
+	return m, nil
+}
+
+// newTable creates an empty table with the current blinds.
+func (m *MultiTableTournament) newTable() *Table {
+	if m.Tournament != nil {
+		return m.Tournament.NewTable(m.SeatsPerTable)
+	}
+	return NewTable(m.SeatsPerTable, 0, 0, TableOptions{})
+}
+
+// randomEmptySeat picks an empty seat at random, or -1 if the table is full.
+func (m *MultiTableTournament) randomEmptySeat(table *Table) int {
+	var empty []int
+	for i, s := range table.Seats {
+		if s == nil {
+			empty = append(empty, i)
+		}
+	}
+	if len(empty) == 0 {
+		return -1
+	}
+	return empty[m.rng.Intn(len(empty))]
+}
+
+// PlayersRemaining returns the number of players still seated.
+func (m *MultiTableTournament) PlayersRemaining() int {
+	n := 0
+	for _, table := range m.Tables {
+		n += table.NumPlayers()
+	}
+	return n
+}
+
+// IsFinalTable returns true once every remaining player is at one table.
+func (m *MultiTableTournament) IsFinalTable() bool {
+	return len(m.Tables) == 1
+}
+
+// FindPlayer returns the table and seat of a player, or nil and -1.
+func (m *MultiTableTournament) FindPlayer(player *Player) (*Table, int) {
+	for _, table := range m.Tables {
+		for i, s := range table.Seats {
+			if s != nil && s.Player == player {
+				return table, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// Bust removes a player who has been eliminated. Call Balance afterwards
+// (typically between hands) to move players and break tables.
+func (m *MultiTableTournament) Bust(player *Player) error {
+	table, seat := m.FindPlayer(player)
+	if table == nil {
+		return ErrInvalidSeat
+	}
+	_, err := table.Leave(seat)
+	return err
+}
+
+// Balance breaks tables that are no longer needed and evens out table sizes,
+// returning the moves made. When there are more tables than the remaining
+// players need, the shortest table is broken and its players are sent to the
+// shortest remaining tables; this eventually forms the final table. Then,
+// while the largest table has two or more players more than the shortest, the
+// player due the big blind next at the largest table moves to the shortest.
+// If a player can't be seated, such as when the tables have been changed
+// directly, Balance stops and returns the moves made so far with the error;
+// the player stays where they were.
+func (m *MultiTableTournament) Balance() ([]SeatMove, error) {
+	var moves []SeatMove
+
+	needed := (m.PlayersRemaining() + m.SeatsPerTable - 1) / m.SeatsPerTable
+	if needed < 1 {
+		needed = 1
+	}
+	for len(m.Tables) > needed {
+		broken, err := m.breakTable(m.shortestTable())
+		moves = append(moves, broken...)
+		if err != nil {
+			return moves, err
+		}
+	}
+
+	for {
+		longest, shortest := m.longestTable(), m.shortestTable()
+		if longest.NumPlayers()-shortest.NumPlayers() <= 1 {
+			break
+		}
+		seat := longest.NextBigBlindSeat()
+		if seat < 0 {
+			break
+		}
+		move, err := m.move(longest, seat, shortest)
+		if err != nil {
+			return moves, err
+		}
+		moves = append(moves, move)
+	}
+
+	return moves, nil
+}
+
+// breakTable moves every player off a table and removes it. If a player
+// can't be moved, the table is kept with the players still at it.
+func (m *MultiTableTournament) breakTable(table *Table) ([]SeatMove, error) {
+	i := slices.Index(m.Tables, table)
+	m.Tables = slices.Delete(m.Tables, i, i+1)
+
+	var moves []SeatMove
+	for seat, s := range table.Seats {
+		if s == nil {
+			continue
+		}
+		move, err := m.move(table, seat, m.shortestTable())
+		if err != nil {
+			m.Tables = slices.Insert(m.Tables, i, table)
+			return moves, err
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// move seats a player from one table at a random empty seat of another. If
+// the player can't be seated there they keep their seat.
+func (m *MultiTableTournament) move(from *Table, seat int, to *Table) (SeatMove, error) {
+	s, err := from.Leave(seat)
+	if err != nil {
+		return SeatMove{}, err
+	}
+	toSeat := m.randomEmptySeat(to)
+	if err := to.Sit(toSeat, s.Player, s.Stack); err != nil {
+		from.Seats[seat] = s
+		return SeatMove{}, err
+	}
+	return SeatMove{Player: s.Player, From: from, FromSeat: seat, To: to, ToSeat: toSeat}, nil
+}
+
+// shortestTable returns the table with the fewest players, preferring the
+// last such table so earlier tables stay stable.
+func (m *MultiTableTournament) shortestTable() *Table {
+	shortest := m.Tables[0]
+	for _, table := range m.Tables[1:] {
+		if table.NumPlayers() <= shortest.NumPlayers() {
+			shortest = table
+		}
+	}
+	return shortest
+}
+
+// longestTable returns the first table with the most players.
+func (m *MultiTableTournament) longestTable() *Table {
+	longest := m.Tables[0]
+	for _, table := range m.Tables[1:] {
+		if table.NumPlayers() > longest.NumPlayers() {
+			longest = table
+		}
+	}
+	return longest
+}
@@ -0,0 +1,255 @@
+package goker
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func newTestPlayers(n int) []*Player {
+	players := make([]*Player, n)
+	for i := range players {
+		players[i] = NewPlayer(fmt.Sprintf("P%d", i+1))
+	}
+	return players
+}
+
+func newTestMTT(t *testing.T, numPlayers, seatsPerTable int, seed int64) (*MultiTableTournament, []*Player) {
+	t.Helper()
+	players := newTestPlayers(numPlayers)
+	m, err := NewMultiTableTournament(players, 1000, seatsPerTable, nil, seed)
+	if err != nil {
+		t.Fatalf("NewMultiTableTournament() error = %v", err)
+	}
+	return m, players
+}
+
+// tableSizes returns the number of players at each table.
+func tableSizes(m *MultiTableTournament) []int {
+	sizes := make([]int, len(m.Tables))
+	for i, table := range m.Tables {
+		sizes[i] = table.NumPlayers()
+	}
+	return sizes
+}
+
+// checkBalanced fails if table sizes differ by more than one.
+func checkBalanced(t *testing.T, m *MultiTableTournament) {
+	t.Helper()
+	sizes := tableSizes(m)
+	lo, hi := sizes[0], sizes[0]
+	for _, n := range sizes {
+		if n < lo {
+			lo = n
+		}
+		if n > hi {
+			hi = n
+		}
+	}
+	if hi-lo > 1 {
+		t.Errorf("Table sizes %v are not balanced", sizes)
+	}
+}
+
+func TestNewMultiTableTournament(t *testing.T) {
+	m, players := newTestMTT(t, 20, 9, 1)
+
+	if len(m.Tables) != 3 {
+		t.Fatalf("%d tables, want 3", len(m.Tables))
+	}
+	checkBalanced(t, m)
+	if m.PlayersRemaining() != 20 {
+		t.Errorf("PlayersRemaining() = %d, want 20", m.PlayersRemaining())
+	}
+	for _, p := range players {
+		table, seat := m.FindPlayer(p)
+		if table == nil || table.Seats[seat].Stack != 1000 {
+			t.Errorf("%v is not seated with 1000 chips", p)
+		}
+	}
+	for i, table := range m.Tables {
+		if table.Seats[table.Button] == nil {
+			t.Errorf("Table %d button is on an empty seat", i)
+		}
+	}
+
+	if _, err := NewMultiTableTournament(newTestPlayers(1), 1000, 9, nil, 1); err != ErrNotEnoughPlayers {
+		t.Errorf("NewMultiTableTournament() with 1 player error = %v, want ErrNotEnoughPlayers", err)
+	}
+	if _, err := NewMultiTableTournament(newTestPlayers(4), 1000, 1, nil, 1); err != ErrInvalidSeat {
+		t.Errorf("NewMultiTableTournament() with 1 seat error = %v, want ErrInvalidSeat", err)
+	}
+	for _, stack := range []int{0, -100} {
+		if _, err := NewMultiTableTournament(newTestPlayers(4), stack, 9, nil, 1); err != ErrInvalidStacks {
+			t.Errorf("NewMultiTableTournament() with a stack of %d error = %v, want ErrInvalidStacks", stack, err)
+		}
+	}
+}
+
+func TestMultiTableTournamentTableBlinds(t *testing.T) {
+	tourney, _ := newTestTournament(t)
+	m, err := NewMultiTableTournament(newTestPlayers(12), 1000, 6, tourney, 1)
+	if err != nil {
+		t.Fatalf("NewMultiTableTournament() error = %v", err)
+	}
+	for _, table := range m.Tables {
+		if table.SmallBlind != 25 || table.BigBlind != 50 {
+			t.Errorf("Table blinds = %d/%d, want 25/50", table.SmallBlind, table.BigBlind)
+		}
+	}
+}
+
+func TestMultiTableTournamentBalance(t *testing.T) {
+	m, _ := newTestMTT(t, 12, 6, 1)
+	long, short := m.Tables[0], m.Tables[1]
+
+	// Bust three players from the second table, leaving 6 and 3
+	for i := 0; i < 3; i++ {
+		seat := short.activeSeats(0)[0]
+		if err := m.Bust(short.Seats[seat].Player); err != nil {
+			t.Fatalf("Bust() error = %v", err)
+		}
+	}
+
+	due := long.NextBigBlindSeat()
+	duePlayer := long.Seats[due].Player
+
+	moves, err := m.Balance()
+	if err != nil {
+		t.Fatalf("Balance() error = %v", err)
+	}
+	if len(moves) != 1 {
+		t.Fatalf("Balance() made %d moves, want 1", len(moves))
+	}
+	move := moves[0]
+	if move.Player != duePlayer || move.From != long || move.FromSeat != due || move.To != short {
+		t.Errorf("Balance() move = %+v, want the player due the big blind moved to the short table", move)
+	}
+	if table, seat := m.FindPlayer(duePlayer); table != short || seat != move.ToSeat {
+		t.Errorf("Moved player is at seat %d of %v", seat, table)
+	}
+	if sizes := tableSizes(m); sizes[0] != 5 || sizes[1] != 4 {
+		t.Errorf("Table sizes = %v, want [5 4]", sizes)
+	}
+
+	if moves, err := m.Balance(); err != nil || len(moves) != 0 {
+		t.Errorf("Balance() on balanced tables made %d moves, error = %v", len(moves), err)
+	}
+}
+
+func TestMultiTableTournamentMoveFails(t *testing.T) {
+	m, _ := newTestMTT(t, 12, 6, 1)
+	long, short := m.Tables[0], m.Tables[1]
+	for i := 0; i < 3; i++ {
+		m.Bust(short.Seats[short.activeSeats(0)[0]].Player)
+	}
+
+	// Shrinking the short table's seats directly leaves nowhere to move to
+	short.Seats = slices.DeleteFunc(short.Seats, func(s *Seat) bool { return s == nil })
+	due := long.NextBigBlindSeat()
+	duePlayer := long.Seats[due].Player
+
+	moves, err := m.Balance()
+	if err != ErrInvalidSeat || len(moves) != 0 {
+		t.Fatalf("Balance() to a full table = %v, error = %v, want ErrInvalidSeat", moves, err)
+	}
+	if table, seat := m.FindPlayer(duePlayer); table != long || seat != due {
+		t.Errorf("Player who couldn't move is at seat %d of %v, want their seat kept", seat, table)
+	}
+	if m.PlayersRemaining() != 9 {
+		t.Errorf("PlayersRemaining() = %d, want 9", m.PlayersRemaining())
+	}
+}
+
+func TestMultiTableTournamentBreakTables(t *testing.T) {
+	m, players := newTestMTT(t, 27, 9, 7)
+
+	// Bust players one at a time until the final table forms
+	busted := 0
+	for _, p := range players {
+		if m.PlayersRemaining() == 9 {
+			break
+		}
+		if err := m.Bust(p); err != nil {
+			t.Fatalf("Bust() error = %v", err)
+		}
+		busted++
+
+		moves, err := m.Balance()
+		if err != nil {
+			t.Fatalf("Balance() error = %v", err)
+		}
+		chips := 0
+		for _, move := range moves {
+			if move.To.Seats[move.ToSeat].Player != move.Player {
+				t.Errorf("%v was not seated at seat %d", move.Player, move.ToSeat)
+			}
+			chips += move.To.Seats[move.ToSeat].Stack
+		}
+		if chips%1000 != 0 {
+			t.Errorf("Moved players' stacks = %d, moving should keep stacks", chips)
+		}
+
+		needed := (m.PlayersRemaining() + 8) / 9
+		if len(m.Tables) != needed {
+			t.Errorf("%d players on %d tables, want %d", m.PlayersRemaining(), len(m.Tables), needed)
+		}
+		checkBalanced(t, m)
+		for _, table := range m.Tables {
+			if table.NumPlayers() > 9 {
+				t.Errorf("Table has %d players", table.NumPlayers())
+			}
+		}
+	}
+
+	if busted != 18 || !m.IsFinalTable() {
+		t.Errorf("After %d busts, %d tables remain, want a final table", busted, len(m.Tables))
+	}
+	if err := m.Bust(players[0]); err != ErrInvalidSeat {
+		t.Errorf("Bust() of a busted player error = %v, want ErrInvalidSeat", err)
+	}
+}
+
+func TestMultiTableTournamentDeterministic(t *testing.T) {
+	run := func() []string {
+		m, players := newTestMTT(t, 30, 8, 42)
+		var seating []string
+		for _, p := range players[:20] {
+			m.Bust(p)
+			moves, err := m.Balance()
+			if err != nil {
+				t.Fatalf("Balance() error = %v", err)
+			}
+			for _, move := range moves {
+				seating = append(seating, fmt.Sprintf("%s:%d", move.Player.Name, move.ToSeat))
+			}
+		}
+		for _, p := range players[20:] {
+			_, seat := m.FindPlayer(p)
+			seating = append(seating, fmt.Sprintf("%s@%d", p.Name, seat))
+		}
+		return seating
+	}
+
+	a, b := run(), run()
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("Same seed produced different seating:\n%v\n%v", a, b)
+	}
+}
+
+func TestTableNextBigBlindSeat(t *testing.T) {
+	table := newTestTable(t, 6, TableOptions{})
+	if got := table.NextBigBlindSeat(); got != 2 {
+		t.Errorf("NextBigBlindSeat() before the first hand = %d, want 2", got)
+	}
+
+	startTestHand(t, table)
+	if got := table.NextBigBlindSeat(); got != 3 {
+		t.Errorf("NextBigBlindSeat() after a hand = %d, want 3", got)
+	}
+
+	headsUp := newTestTable(t, 2, TableOptions{})
+	if got := headsUp.NextBigBlindSeat(); got != 1 {
+		t.Errorf("Heads-up NextBigBlindSeat() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,121 @@
+package goker
+
+import "sort"
+
+// NutHand is one strength of hand possible on a board, with every hole card
+// combination that makes it.
+type NutHand struct {
+	Hand   *Hand    // The best 5-card hand, as made by the first combination
+	Combos [][]Card // Hole cards making exactly this hand, ties included
+}
+
+// NutRank places a player's hole cards among the hands possible on a board.
+type NutRank struct {
+	// Nth is 1 for the nuts, 2 for the second nuts and so on, out of Of
+	// distinct hands that any hole cards make on the board.
+	Nth int
+	Of  int
+
+	// Beats, Ties and Loses count the opponent hole card combinations left
+	// once the player's cards and the board are removed: 1,081 on the flop.
+	Beats     int
+	Ties      int
+	Loses     int
+	Opponents int
+}
+
+// BeatFraction returns the share of opponent holdings the hand beats.
+func (r *NutRank) BeatFraction() float64 {
+	return float64(r.Beats) / float64(r.Opponents)
+}
+
+// NutHands lists every hand the hole cards left can make on a flop, turn or
+// river, best first: the nuts, then the second nuts and so on. Hole cards
+// that tie share an entry. Blockers aren't considered, so every pair of
+// cards not on the board counts.
+func NutHands(board *Board) ([]NutHand, error) {
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	scores, groups := scoreHoleCards(board.Cards, 0)
+
+	nuts := make([]NutHand, len(scores))
+	for i, score := range scores {
+		combos := groups[score]
+		nuts[i] = NutHand{
+			Hand:   findBestHand(append(append([]Card(nil), combos[0]...), board.Cards...)),
+			Combos: combos,
+		}
+	}
+	return nuts, nil
+}
+
+// NutRanking returns where hole cards stand on a flop, turn or river: how
+// many distinct hands beat them and how many opponent holdings they beat.
+func NutRanking(holeCards []Card, board *Board) (*NutRank, error) {
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	if err := validateDeal([][]Card{holeCards}, board.Cards, nil); err != nil {
+		return nil, err
+	}
+	mine := evaluateCards(append(append([]Card(nil), holeCards...), board.Cards...))
+
+	scores, _ := scoreHoleCards(board.Cards, 0)
+	rank := &NutRank{Of: len(scores)}
+	rank.Nth = sort.Search(len(scores), func(i int) bool { return scores[i] <= mine }) + 1
+
+	hole, _ := newCardSet(holeCards)
+	_, groups := scoreHoleCards(board.Cards, hole)
+	for score, combos := range groups {
+		switch {
+		case score < mine:
+			rank.Beats += len(combos)
+		case score == mine:
+			rank.Ties += len(combos)
+		default:
+			rank.Loses += len(combos)
+		}
+		rank.Opponents += len(combos)
+	}
+	return rank, nil
+}
+
+// scoreHoleCards scores every pair of cards not on the board or excluded,
+// returning the distinct scores best first and the hole cards making each.
+func scoreHoleCards(board []Card, excluded cardSet) ([]handScore, map[handScore][][]Card) {
+	used, _ := newCardSet(board)
+	deck := (fullCardSet &^ used &^ excluded).cards()
+
+	cards := append(append([]Card(nil), board...), Card{}, Card{})
+	groups := make(map[handScore][][]Card)
+	var buf [2]Card
+	for hole := range CombinationsBuffer(deck, 2, buf[:]) {
+		copy(cards[len(board):], hole)
+		score := evaluateCards(cards)
+		groups[score] = append(groups[score], []Card{hole[0], hole[1]})
+	}
+
+	scores := make([]handScore, 0, len(groups))
+	for score := range groups {
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i] > scores[j] })
+	return scores, groups
+}
+
+// validateBoard checks a flop, turn or river on its own.
+func validateBoard(board *Board) error {
+	if board == nil || len(board.Cards) < 3 || len(board.Cards) > 5 {
+		return ErrInvalidBoardSize
+	}
+	for _, c := range board.Cards {
+		if !validCard(c) {
+			return ErrInvalidCard
+		}
+	}
+	if _, ok := newCardSet(board.Cards); !ok {
+		return ErrDuplicateCards
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package goker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNutHands(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Seven, Diamonds), NewCard(Two, Clubs)}}
+	nuts, err := NutHands(board)
+	if err != nil {
+		t.Fatalf("NutHands error: %v", err)
+	}
+
+	if nuts[0].Hand.Rank() != RoyalFlush || len(nuts[0].Combos) != 1 {
+		t.Errorf("nuts = %v with %d combos, want a royal flush with 1", nuts[0].Hand, len(nuts[0].Combos))
+	}
+	combos := 0
+	for i, n := range nuts {
+		combos += len(n.Combos)
+		if i > 0 && !nuts[i-1].Hand.Beats(n.Hand) {
+			t.Errorf("NutHands[%d] = %v doesn't beat NutHands[%d] = %v", i-1, nuts[i-1].Hand, i, n.Hand)
+		}
+	}
+	if combos != 1081 {
+		t.Errorf("NutHands covers %d combos, want 1081", combos)
+	}
+}
+
+func TestNutRanking(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Seven, Diamonds), NewCard(Two, Clubs)}}
+
+	royal, err := NutRanking([]Card{NewCard(Jack, Spades), NewCard(Ten, Spades)}, board)
+	if err != nil {
+		t.Fatalf("NutRanking error: %v", err)
+	}
+	if royal.Nth != 1 || royal.Loses != 0 || royal.Ties != 0 || royal.BeatFraction() != 1 {
+		t.Errorf("royal flush ranking = %+v, want the nuts beating everything", royal)
+	}
+	if royal.Opponents != 990 {
+		t.Errorf("Opponents = %d, want 990", royal.Opponents)
+	}
+
+	aces := []Card{NewCard(Ace, Hearts), NewCard(Ace, Diamonds)}
+	set, err := NutRanking(aces, board)
+	if err != nil {
+		t.Fatalf("NutRanking error: %v", err)
+	}
+	nuts, _ := NutHands(board)
+	if set.Of != len(nuts) || !slices.ContainsFunc(nuts[set.Nth-1].Combos, func(c []Card) bool {
+		return slices.Equal(c, aces) || slices.Equal(c, []Card{aces[1], aces[0]})
+	}) {
+		t.Errorf("set of aces ranked %d of %d, not where NutHands lists it", set.Nth, set.Of)
+	}
+	if set.Beats+set.Ties+set.Loses != set.Opponents || set.Ties != 0 {
+		t.Errorf("set of aces ranking = %+v", set)
+	}
+	if f := set.BeatFraction(); f <= 0.5 || f >= 1 {
+		t.Errorf("set of aces beats %.3f of holdings", f)
+	}
+}
+
+func TestNutRankingFlop(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(Two, Clubs), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)}}
+	rank, err := NutRanking([]Card{NewCard(Two, Hearts), NewCard(Three, Spades)}, board)
+	if err != nil {
+		t.Fatalf("NutRanking error: %v", err)
+	}
+	if rank.Opponents != 1081 {
+		t.Errorf("Opponents = %d, want 1081", rank.Opponents)
+	}
+	if rank.Nth == 1 || rank.Beats == 0 || rank.Loses == 0 {
+		t.Errorf("bottom pair ranking = %+v", rank)
+	}
+}
+
+func TestNutRankingInvalid(t *testing.T) {
+	hole := []Card{NewCard(Ace, Spades), NewCard(King, Spades)}
+	if _, err := NutRanking(hole, NewBoard()); !errors.Is(err, ErrInvalidBoardSize) {
+		t.Errorf("NutRanking preflop error = %v, want ErrInvalidBoardSize", err)
+	}
+	board := &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(Two, Clubs), NewCard(Three, Clubs)}}
+	if _, err := NutRanking(hole, board); !errors.Is(err, ErrDuplicateCards) {
+		t.Errorf("NutRanking with duplicates error = %v, want ErrDuplicateCards", err)
+	}
+	if _, err := NutHands(nil); !errors.Is(err, ErrInvalidBoardSize) {
+		t.Errorf("NutHands without a board error = %v, want ErrInvalidBoardSize", err)
+	}
+}
@@ -0,0 +1,307 @@
+package goker
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	topRowSize    = 3
+	ofcRowPoints  = 1
+	ofcScoopBonus = 3
+)
+
+// OFCRow identifies one of the three rows of an open-face Chinese poker hand.
+type OFCRow int
+
+const (
+	TopRow OFCRow = iota + 1
+	MiddleRow
+	BottomRow
+)
+
+func (r OFCRow) String() string {
+	switch r {
+	case TopRow:
+		return "Top"
+	case MiddleRow:
+		return "Middle"
+	case BottomRow:
+		return "Bottom"
+	default:
+		return "Unknown"
+	}
+}
+
+// Royalty tables for open-face Chinese poker, keyed by hand rank.
+var (
+	bottomRoyalties = map[HandRank]int{
+		Straight:      2,
+		Flush:         4,
+		FullHouse:     6,
+		FourOfAKind:   10,
+		StraightFlush: 15,
+		RoyalFlush:    25,
+	}
+
+	middleRoyalties = map[HandRank]int{
+		ThreeOfAKind:  2,
+		Straight:      4,
+		Flush:         8,
+		FullHouse:     12,
+		FourOfAKind:   20,
+		StraightFlush: 30,
+		RoyalFlush:    50,
+	}
+)
+
+// TopHand represents the 3-card top row of an open-face Chinese poker hand.
+// Straights and flushes do not count in the top row, so it can only be
+// High Card, Pair or Three of a Kind. Ranks and tiebreak scores use the same
+// scale as Hand so the top row can be compared directly with the middle row.
+type TopHand struct {
+	Cards []Card
+
+	handRank HandRank
+}
+
+// NewTopHand creates a new TopHand from 3 cards.
+func NewTopHand(cards []Card) (*TopHand, error) {
+	if len(cards) != topRowSize {
+		return nil, ErrInvalidOFCRow
+	}
+
+	if hasDuplicateCards(cards) {
+		return nil, ErrDuplicateCards
+	}
+
+	sortedCards := make([]Card, len(cards))
+	copy(sortedCards, cards)
+	sort.Slice(sortedCards, func(i, j int) bool {
+		return sortedCards[i].Rank > sortedCards[j].Rank
+	})
+
+	h := &TopHand{Cards: sortedCards}
+	switch {
+	case sortedCards[0].Rank == sortedCards[2].Rank:
+		h.handRank = ThreeOfAKind
+	case sortedCards[0].Rank == sortedCards[1].Rank || sortedCards[1].Rank == sortedCards[2].Rank:
+		h.handRank = Pair
+	default:
+		h.handRank = HighCard
+	}
+	return h, nil
+}
+
+// Rank returns the evaluated hand rank.
+func (h *TopHand) Rank() HandRank {
+	return h.handRank
+}
+
+// TiebreakScore returns a numeric score for breaking ties between hands of equal rank.
+func (h *TopHand) TiebreakScore() int {
+	return tiebreakScore(h.Cards)
+}
+
+// Compare compares two top rows. Returns -1, 0 or 1 like Hand.Compare.
+func (h *TopHand) Compare(other *TopHand) int {
+	return compareRankAndScore(h.handRank, h.TiebreakScore(), other.handRank, other.TiebreakScore())
+}
+
+// CompareHand compares the top row with a 5-card hand. Returns -1, 0 or 1
+// like Hand.Compare. Missing kickers count as lower than any card, so a top
+// row of QQ3 loses to QQ432 but beats QQ2.
+func (h *TopHand) CompareHand(other *Hand) int {
+	return compareRankAndScore(h.handRank, h.TiebreakScore(), other.handRank, other.TiebreakScore())
+}
+
+// pairRank returns the rank of the pair or trips in the top row.
+func (h *TopHand) pairRank() CardRank {
+	return h.Cards[1].Rank
+}
+
+// String returns a string representation of the top row.
+func (h *TopHand) String() string {
+	s := "<TopHand: "
+	for i, card := range h.Cards {
+		if i > 0 {
+			s += " "
+		}
+		s += card.String()
+	}
+	s += ">"
+	return s
+}
+
+func compareRankAndScore(rank HandRank, score int, otherRank HandRank, otherScore int) int {
+	if rank > otherRank {
+		return 1
+	}
+	if rank < otherRank {
+		return -1
+	}
+	if score > otherScore {
+		return 1
+	}
+	if score < otherScore {
+		return -1
+	}
+	return 0
+}
+
+// OFCHand represents a complete open-face Chinese poker hand.
+type OFCHand struct {
+	Top    *TopHand
+	Middle *Hand
+	Bottom *Hand
+}
+
+// NewOFCHand creates an OFC hand from a 3-card top row and 5-card middle and bottom rows.
+func NewOFCHand(top, middle, bottom []Card) (*OFCHand, error) {
+	if len(top) != topRowSize || len(middle) != handSize || len(bottom) != handSize {
+		return nil, ErrInvalidOFCRow
+	}
+
+	if hasDuplicateCards(top, middle, bottom) {
+		return nil, ErrDuplicateCards
+	}
+
+	topHand, err := NewTopHand(top)
+	if err != nil {
+		return nil, err
+	}
+	middleHand, err := NewHand(middle)
+	if err != nil {
+		return nil, err
+	}
+	bottomHand, err := NewHand(bottom)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OFCHand{Top: topHand, Middle: middleHand, Bottom: bottomHand}, nil
+}
+
+// IsFoul returns true if the rows are not in ascending strength
+// (the bottom must be at least as strong as the middle, and the middle
+// at least as strong as the top).
+func (h *OFCHand) IsFoul() bool {
+	return h.Top.CompareHand(h.Middle) > 0 || h.Middle.Compare(h.Bottom) > 0
+}
+
+// Royalties returns the total royalty bonus for the hand.
+// Fouled hands earn no royalties.
+func (h *OFCHand) Royalties() int {
+	if h.IsFoul() {
+		return 0
+	}
+	return TopRoyalty(h.Top) + MiddleRoyalty(h.Middle) + BottomRoyalty(h.Bottom)
+}
+
+// QualifiesForFantasyland returns true if the hand earns fantasyland:
+// a pair of queens or better in the top row without fouling.
+func (h *OFCHand) QualifiesForFantasyland() bool {
+	if h.IsFoul() {
+		return false
+	}
+	return h.Top.Rank() == ThreeOfAKind || (h.Top.Rank() == Pair && h.Top.pairRank() >= Queen)
+}
+
+// StaysInFantasyland returns true if a hand played in fantasyland earns
+// another fantasyland hand: trips in the top row, a full house or better
+// in the middle, or four of a kind or better in the bottom.
+func (h *OFCHand) StaysInFantasyland() bool {
+	if h.IsFoul() {
+		return false
+	}
+	return h.Top.Rank() == ThreeOfAKind ||
+		h.Middle.Rank() >= FullHouse ||
+		h.Bottom.Rank() >= FourOfAKind
+}
+
+// TopRoyalty returns the royalty for a top row: 1 point for 66 up to 9 for AA,
+// and 10 points for 222 up to 22 for AAA.
+func TopRoyalty(h *TopHand) int {
+	switch h.Rank() {
+	case ThreeOfAKind:
+		return int(h.pairRank()) + 8
+	case Pair:
+		if h.pairRank() >= Six {
+			return int(h.pairRank()) - 5
+		}
+	}
+	return 0
+}
+
+// MiddleRoyalty returns the royalty for a middle row.
+func MiddleRoyalty(h *Hand) int {
+	return middleRoyalties[h.Rank()]
+}
+
+// BottomRoyalty returns the royalty for a bottom row.
+func BottomRoyalty(h *Hand) int {
+	return bottomRoyalties[h.Rank()]
+}
+
+// compareRow compares one row of two OFC hands.
+func (h *OFCHand) compareRow(other *OFCHand, row OFCRow) int {
+	switch row {
+	case TopRow:
+		return h.Top.Compare(other.Top)
+	case MiddleRow:
+		return h.Middle.Compare(other.Middle)
+	default:
+		return h.Bottom.Compare(other.Bottom)
+	}
+}
+
+// ScoreOFC scores hand a against hand b and returns the points won by a
+// (b wins the negation). Each row is worth 1 point, winning all three rows
+// scores a 3 point scoop bonus, and royalties are paid on top. A fouled hand
+// loses every row and earns no royalties; two fouled hands score 0.
+func ScoreOFC(a, b *OFCHand) int {
+	aFoul, bFoul := a.IsFoul(), b.IsFoul()
+	if aFoul && bFoul {
+		return 0
+	}
+
+	royalties := a.Royalties() - b.Royalties()
+	if aFoul {
+		return -3*ofcRowPoints - ofcScoopBonus + royalties
+	}
+	if bFoul {
+		return 3*ofcRowPoints + ofcScoopBonus + royalties
+	}
+
+	points := 0
+	for _, row := range []OFCRow{TopRow, MiddleRow, BottomRow} {
+		points += a.compareRow(b, row) * ofcRowPoints
+	}
+	switch points {
+	case 3 * ofcRowPoints:
+		points += ofcScoopBonus
+	case -3 * ofcRowPoints:
+		points -= ofcScoopBonus
+	}
+
+	return points + royalties
+}
+
+// ScoreOFCTable scores every hand against every other hand and returns the
+// net points for each player. The scores always sum to zero.
+func ScoreOFCTable(hands []*OFCHand) []int {
+	scores := make([]int, len(hands))
+	for i := 0; i < len(hands); i++ {
+		for j := i + 1; j < len(hands); j++ {
+			points := ScoreOFC(hands[i], hands[j])
+			scores[i] += points
+			scores[j] -= points
+		}
+	}
+	return scores
+}
+
+// String returns a string representation of the OFC hand.
+func (h *OFCHand) String() string {
+	return fmt.Sprintf("<OFCHand: %v %v %v>", h.Top, h.Middle, h.Bottom)
+}
@@ -0,0 +1,310 @@
+package goker
+
+import "testing"
+
+func makeOFCHand(t *testing.T, top, middle, bottom []Card) *OFCHand {
+	t.Helper()
+	h, err := NewOFCHand(top, middle, bottom)
+	if err != nil {
+		t.Fatalf("Failed to create OFC hand: %v", err)
+	}
+	return h
+}
+
+func TestNewTopHand(t *testing.T) {
+	tests := []struct {
+		cards []Card
+		rank  HandRank
+	}{
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Hearts), NewCard(Queen, Spades)}, HighCard},
+		{[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Two, Clubs)}, Pair},
+		{[]Card{NewCard(Ace, Spades), NewCard(Five, Hearts), NewCard(Five, Clubs)}, Pair},
+		{[]Card{NewCard(Seven, Spades), NewCard(Seven, Hearts), NewCard(Seven, Clubs)}, ThreeOfAKind},
+		// Straights and flushes don't count in the top row
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades)}, HighCard},
+	}
+
+	for _, tt := range tests {
+		h, err := NewTopHand(tt.cards)
+		if err != nil {
+			t.Fatalf("NewTopHand(%v) error = %v", tt.cards, err)
+		}
+		if h.Rank() != tt.rank {
+			t.Errorf("NewTopHand(%v).Rank() = %v, want %v", tt.cards, h.Rank(), tt.rank)
+		}
+	}
+}
+
+func TestNewTopHandInvalid(t *testing.T) {
+	_, err := NewTopHand([]Card{NewCard(Ace, Spades), NewCard(King, Spades)})
+	if err != ErrInvalidOFCRow {
+		t.Errorf("NewTopHand() error = %v, want ErrInvalidOFCRow", err)
+	}
+
+	_, err = NewTopHand([]Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(King, Spades)})
+	if err != ErrDuplicateCards {
+		t.Errorf("NewTopHand() error = %v, want ErrDuplicateCards", err)
+	}
+}
+
+func TestTopHandCompareHand(t *testing.T) {
+	top, _ := NewTopHand([]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Three, Clubs)})
+
+	tests := []struct {
+		middle []Card
+		want   int
+	}{
+		// QQ3 vs QQ432: the middle's kickers play
+		{[]Card{NewCard(Queen, Diamonds), NewCard(Queen, Clubs), NewCard(Four, Hearts), NewCard(Three, Spades), NewCard(Two, Spades)}, -1},
+		// Pair of queens beats pair of jacks
+		{[]Card{NewCard(Jack, Diamonds), NewCard(Jack, Clubs), NewCard(Ace, Hearts), NewCard(King, Spades), NewCard(Two, Spades)}, 1},
+		// Pair vs two pair
+		{[]Card{NewCard(Two, Diamonds), NewCard(Two, Clubs), NewCard(Three, Hearts), NewCard(Three, Spades), NewCard(Four, Spades)}, -1},
+	}
+
+	for _, tt := range tests {
+		middle := makeHand(t, tt.middle...)
+		if got := top.CompareHand(middle); got != tt.want {
+			t.Errorf("%v.CompareHand(%v) = %d, want %d", top, middle, got, tt.want)
+		}
+	}
+
+	kickerTop, _ := NewTopHand([]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Five, Clubs)})
+	middle := makeHand(t,
+		NewCard(Queen, Diamonds), NewCard(Queen, Clubs), NewCard(Four, Hearts), NewCard(Three, Spades), NewCard(Two, Spades))
+	if kickerTop.CompareHand(middle) != 1 {
+		t.Errorf("QQ5 top should beat QQ432 middle")
+	}
+}
+
+func TestNewOFCHandInvalid(t *testing.T) {
+	top := []Card{NewCard(Ace, Spades), NewCard(King, Spades), NewCard(Queen, Spades)}
+	middle := []Card{NewCard(Two, Hearts), NewCard(Three, Hearts), NewCard(Four, Hearts), NewCard(Five, Hearts), NewCard(Seven, Clubs)}
+	bottom := []Card{NewCard(Two, Clubs), NewCard(Three, Clubs), NewCard(Four, Clubs), NewCard(Five, Clubs), NewCard(Ace, Spades)}
+
+	_, err := NewOFCHand(top[:2], middle, bottom)
+	if err != ErrInvalidOFCRow {
+		t.Errorf("NewOFCHand() error = %v, want ErrInvalidOFCRow", err)
+	}
+
+	_, err = NewOFCHand(top, middle, bottom)
+	if err != ErrDuplicateCards {
+		t.Errorf("NewOFCHand() error = %v, want ErrDuplicateCards", err)
+	}
+}
+
+func TestOFCHandIsFoul(t *testing.T) {
+	valid := makeOFCHand(t,
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Two, Clubs)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds), NewCard(Six, Diamonds)},
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Five, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	if valid.IsFoul() {
+		t.Error("Ascending hand should not be foul")
+	}
+
+	topTooStrong := makeOFCHand(t,
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Two, Clubs)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds), NewCard(Six, Diamonds)},
+		[]Card{NewCard(Nine, Spades), NewCard(Nine, Hearts), NewCard(Nine, Clubs), NewCard(Five, Diamonds), NewCard(Seven, Diamonds)},
+	)
+	if !topTooStrong.IsFoul() {
+		t.Error("Top stronger than middle should be foul")
+	}
+
+	middleTooStrong := makeOFCHand(t,
+		[]Card{NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Clubs)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(King, Clubs), NewCard(Four, Diamonds), NewCard(Six, Diamonds)},
+		[]Card{NewCard(Nine, Spades), NewCard(Nine, Hearts), NewCard(Eight, Clubs), NewCard(Eight, Diamonds), NewCard(Seven, Diamonds)},
+	)
+	if !middleTooStrong.IsFoul() {
+		t.Error("Middle stronger than bottom should be foul")
+	}
+}
+
+func TestTopRoyalty(t *testing.T) {
+	tests := []struct {
+		cards []Card
+		want  int
+	}{
+		{[]Card{NewCard(Five, Spades), NewCard(Five, Hearts), NewCard(Ace, Clubs)}, 0},
+		{[]Card{NewCard(Six, Spades), NewCard(Six, Hearts), NewCard(Two, Clubs)}, 1},
+		{[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Two, Clubs)}, 7},
+		{[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Two, Clubs)}, 9},
+		{[]Card{NewCard(Two, Spades), NewCard(Two, Hearts), NewCard(Two, Clubs)}, 10},
+		{[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs)}, 22},
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Hearts), NewCard(Two, Clubs)}, 0},
+	}
+
+	for _, tt := range tests {
+		h, _ := NewTopHand(tt.cards)
+		if got := TopRoyalty(h); got != tt.want {
+			t.Errorf("TopRoyalty(%v) = %d, want %d", h, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleAndBottomRoyalty(t *testing.T) {
+	flush := makeHand(t, NewCard(Two, Hearts), NewCard(Five, Hearts), NewCard(Nine, Hearts), NewCard(Jack, Hearts), NewCard(King, Hearts))
+	if got := MiddleRoyalty(flush); got != 8 {
+		t.Errorf("MiddleRoyalty(flush) = %d, want 8", got)
+	}
+	if got := BottomRoyalty(flush); got != 4 {
+		t.Errorf("BottomRoyalty(flush) = %d, want 4", got)
+	}
+
+	trips := makeHand(t, NewCard(Two, Hearts), NewCard(Two, Clubs), NewCard(Two, Spades), NewCard(Jack, Hearts), NewCard(King, Hearts))
+	if got := MiddleRoyalty(trips); got != 2 {
+		t.Errorf("MiddleRoyalty(trips) = %d, want 2", got)
+	}
+	if got := BottomRoyalty(trips); got != 0 {
+		t.Errorf("BottomRoyalty(trips) = %d, want 0", got)
+	}
+}
+
+func TestOFCHandRoyaltiesAndFantasyland(t *testing.T) {
+	h := makeOFCHand(t,
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Two, Clubs)},
+		[]Card{NewCard(Three, Hearts), NewCard(Six, Hearts), NewCard(Nine, Hearts), NewCard(Jack, Hearts), NewCard(King, Hearts)},
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Ace, Diamonds), NewCard(Nine, Diamonds)},
+	)
+
+	// QQ top (7) + flush middle (8) + quads bottom (10)
+	if got := h.Royalties(); got != 25 {
+		t.Errorf("Royalties() = %d, want 25", got)
+	}
+	if !h.QualifiesForFantasyland() {
+		t.Error("QQ top should qualify for fantasyland")
+	}
+	if !h.StaysInFantasyland() {
+		t.Error("Quads bottom should stay in fantasyland")
+	}
+
+	jacks := makeOFCHand(t,
+		[]Card{NewCard(Jack, Spades), NewCard(Jack, Clubs), NewCard(Two, Clubs)},
+		[]Card{NewCard(Three, Hearts), NewCard(Six, Hearts), NewCard(Nine, Hearts), NewCard(Jack, Hearts), NewCard(King, Hearts)},
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Ace, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	if jacks.QualifiesForFantasyland() {
+		t.Error("JJ top should not qualify for fantasyland")
+	}
+
+	fouled := makeOFCHand(t,
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs)},
+		[]Card{NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Five, Hearts), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(King, Clubs), NewCard(King, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	if fouled.Royalties() != 0 {
+		t.Errorf("Fouled hand royalties = %d, want 0", fouled.Royalties())
+	}
+	if fouled.QualifiesForFantasyland() || fouled.StaysInFantasyland() {
+		t.Error("Fouled hand should not earn fantasyland")
+	}
+}
+
+func TestScoreOFC(t *testing.T) {
+	strong := makeOFCHand(t,
+		[]Card{NewCard(Two, Spades), NewCard(Two, Hearts), NewCard(Four, Clubs)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds), NewCard(Six, Diamonds)},
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Five, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	weak := makeOFCHand(t,
+		[]Card{NewCard(Ace, Diamonds), NewCard(King, Clubs), NewCard(Three, Spades)},
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Seven, Clubs), NewCard(Eight, Diamonds), NewCard(Ten, Diamonds)},
+		[]Card{NewCard(Jack, Spades), NewCard(Jack, Hearts), NewCard(Jack, Clubs), NewCard(Two, Diamonds), NewCard(Three, Diamonds)},
+	)
+
+	// Scoop: 3 rows + 3 bonus, no royalties
+	if got := ScoreOFC(strong, weak); got != 6 {
+		t.Errorf("ScoreOFC(strong, weak) = %d, want 6", got)
+	}
+	if got := ScoreOFC(weak, strong); got != -6 {
+		t.Errorf("ScoreOFC(weak, strong) = %d, want -6", got)
+	}
+
+	split := makeOFCHand(t,
+		[]Card{NewCard(Three, Spades), NewCard(Three, Diamonds), NewCard(Ace, Diamonds)},
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Seven, Spades), NewCard(Eight, Diamonds), NewCard(Ten, Diamonds)},
+		[]Card{NewCard(Two, Clubs), NewCard(Five, Clubs), NewCard(Seven, Clubs), NewCard(Jack, Clubs), NewCard(King, Clubs)},
+	)
+
+	// Top: 33 beats 22 (+1), middle: QQ loses to KK (-1), bottom: flush beats trips (+1)
+	// Royalties: flush bottom (4)
+	if got := ScoreOFC(split, strong); got != 5 {
+		t.Errorf("ScoreOFC(split, strong) = %d, want 5", got)
+	}
+}
+
+func TestScoreOFCFoul(t *testing.T) {
+	fouled := makeOFCHand(t,
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs)},
+		[]Card{NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Five, Hearts), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(King, Clubs), NewCard(King, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	valid := makeOFCHand(t,
+		[]Card{NewCard(Six, Spades), NewCard(Six, Hearts), NewCard(Two, Clubs)},
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Seven, Clubs), NewCard(Eight, Clubs), NewCard(Ten, Diamonds)},
+		[]Card{NewCard(Jack, Spades), NewCard(Jack, Hearts), NewCard(Jack, Clubs), NewCard(Two, Diamonds), NewCard(Three, Diamonds)},
+	)
+
+	// Valid hand scoops the foul (6) plus its 66 top royalty (1)
+	if got := ScoreOFC(valid, fouled); got != 7 {
+		t.Errorf("ScoreOFC(valid, fouled) = %d, want 7", got)
+	}
+	if got := ScoreOFC(fouled, valid); got != -7 {
+		t.Errorf("ScoreOFC(fouled, valid) = %d, want -7", got)
+	}
+	if got := ScoreOFC(fouled, fouled); got != 0 {
+		t.Errorf("ScoreOFC(fouled, fouled) = %d, want 0", got)
+	}
+}
+
+func TestScoreOFCTable(t *testing.T) {
+	a := makeOFCHand(t,
+		[]Card{NewCard(Two, Spades), NewCard(Two, Hearts), NewCard(Four, Clubs)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds), NewCard(Six, Diamonds)},
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs), NewCard(Five, Diamonds), NewCard(Nine, Diamonds)},
+	)
+	b := makeOFCHand(t,
+		[]Card{NewCard(Ace, Diamonds), NewCard(King, Clubs), NewCard(Three, Spades)},
+		[]Card{NewCard(Queen, Spades), NewCard(Queen, Hearts), NewCard(Seven, Clubs), NewCard(Eight, Diamonds), NewCard(Ten, Diamonds)},
+		[]Card{NewCard(Jack, Spades), NewCard(Jack, Hearts), NewCard(Jack, Clubs), NewCard(Two, Diamonds), NewCard(Three, Diamonds)},
+	)
+	c := makeOFCHand(t,
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Ace, Clubs)},
+		[]Card{NewCard(Two, Hearts), NewCard(Three, Clubs), NewCard(Five, Hearts), NewCard(Seven, Diamonds), NewCard(Nine, Hearts)},
+		[]Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(King, Clubs), NewCard(King, Diamonds), NewCard(Nine, Diamonds)},
+	)
+
+	scores := ScoreOFCTable([]*OFCHand{a, b, c})
+
+	sum := 0
+	for _, s := range scores {
+		sum += s
+	}
+	if sum != 0 {
+		t.Errorf("Scores %v should sum to zero", scores)
+	}
+	// a scoops b (6) and the fouled c (6)
+	if scores[0] != 12 {
+		t.Errorf("scores[0] = %d, want 12", scores[0])
+	}
+}
+
+func TestOFCRowString(t *testing.T) {
+	tests := []struct {
+		row      OFCRow
+		expected string
+	}{
+		{TopRow, "Top"},
+		{MiddleRow, "Middle"},
+		{BottomRow, "Bottom"},
+		{OFCRow(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.row.String(); got != tt.expected {
+			t.Errorf("OFCRow(%d).String() = %s, want %s", tt.row, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package goker
+
+// Out is a card that improves a player's share of the pot if it comes next.
+type Out struct {
+	Card  Card
+	Makes HandRank // The player's hand with the card
+	Tie   bool     // The card gives the player a share of the lead, not all of it
+
+	// Clean is false when the card also improves an opponent's hand rank,
+	// so it may help them more on a later street.
+	Clean bool
+}
+
+// PlayerOuts lists a player's outs.
+type PlayerOuts struct {
+	Player int
+	Outs   []Out
+	Clean  int // Number of clean outs
+
+	// Probability is the exact chance that at least one out comes by the
+	// river, and RuleOf2And4 its rule-of-thumb estimate: 4% per out with two
+	// cards to come or 2% with one.
+	Probability float64
+	RuleOf2And4 float64
+}
+
+// ByHand groups the outs by the hand they make.
+func (p PlayerOuts) ByHand() map[HandRank][]Card {
+	byHand := make(map[HandRank][]Card)
+	for _, o := range p.Outs {
+		byHand[o.Makes] = append(byHand[o.Makes], o.Card)
+	}
+	return byHand
+}
+
+// OutsReport describes who is ahead on a flop or turn and which cards change
+// that.
+type OutsReport struct {
+	Leaders []int        // Players with the best hand now, more than one if tied
+	Unseen  int          // Cards not in any player's hand or on the board
+	Players []PlayerOuts // Indexed by player
+}
+
+// CalculateOuts finds every card that would change who is ahead if it came
+// next, for two or more players on a flop or turn. A card is an out for each
+// player whose share of the lead it increases: it puts them ahead, into a
+// tie for the lead, or ahead alone where they were tied. Hole cards and the
+// board are validated as for CalculateWithOptions.
+func CalculateOuts(holeCards [][]Card, board *Board) (*OutsReport, error) {
+	if len(holeCards) < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+	if board == nil {
+		return nil, ErrInvalidBoardSize
+	}
+	if err := validateDeal(holeCards, board.Cards, nil); err != nil {
+		return nil, err
+	}
+	toCome := handSize - len(board.Cards)
+	if toCome != 1 && toCome != 2 {
+		return nil, ErrInvalidBoardSize
+	}
+
+	s := newShowdown(holeCards)
+	now := make([]handScore, len(holeCards))
+	best, leaders := s.score(board.Cards)
+	copy(now, s.scores)
+	shares := leadShares(now, best, leaders)
+
+	unseen := buildRemainingDeck(usedCards(holeCards, board.Cards, nil))
+	report := &OutsReport{Unseen: len(unseen), Players: make([]PlayerOuts, len(holeCards))}
+	for i, share := range shares {
+		if share > 0 {
+			report.Leaders = append(report.Leaders, i)
+		}
+		report.Players[i].Player = i
+	}
+
+	next := append(append([]Card(nil), board.Cards...), Card{})
+	for _, c := range unseen {
+		next[len(next)-1] = c
+		best, leaders := s.score(next)
+		for i, share := range leadShares(s.scores, best, leaders) {
+			if share <= shares[i] {
+				continue
+			}
+			out := Out{Card: c, Makes: s.scores[i].Rank(), Tie: leaders > 1, Clean: true}
+			for j, score := range s.scores {
+				if j != i && score.Rank() > now[j].Rank() {
+					out.Clean = false
+				}
+			}
+			p := &report.Players[i]
+			p.Outs = append(p.Outs, out)
+			if out.Clean {
+				p.Clean++
+			}
+		}
+	}
+
+	for i := range report.Players {
+		p := &report.Players[i]
+		outs := len(p.Outs)
+		missAll := float64(Binomial(len(unseen)-outs, toCome)) / float64(Binomial(len(unseen), toCome))
+		p.Probability = 1 - missAll
+		p.RuleOf2And4 = min(1, float64(outs*2*toCome)/100)
+	}
+	return report, nil
+}
+
+// leadShares returns each player's share of the pot if the hand ended with
+// the given scores.
+func leadShares(scores []handScore, best handScore, leaders int) []float64 {
+	shares := make([]float64, len(scores))
+	for i, score := range scores {
+		if score == best {
+			shares[i] = 1 / float64(leaders)
+		}
+	}
+	return shares
+}
@@ -0,0 +1,129 @@
+package goker
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCalculateOutsFlushDraw(t *testing.T) {
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+		{NewCard(Queen, Spades), NewCard(Queen, Clubs)},
+	}
+	board := &Board{Cards: []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs)}}
+
+	report, err := CalculateOuts(holeCards, board)
+	if err != nil {
+		t.Fatalf("CalculateOuts error: %v", err)
+	}
+	if len(report.Leaders) != 1 || report.Leaders[0] != 1 {
+		t.Errorf("Leaders = %v, want [1]", report.Leaders)
+	}
+	if report.Unseen != 45 {
+		t.Errorf("Unseen = %d, want 45", report.Unseen)
+	}
+
+	// 9 hearts and 6 overcards; the 9 and queen of hearts also improve QQ
+	ak := report.Players[0]
+	if len(ak.Outs) != 15 || ak.Clean != 13 {
+		t.Errorf("AK has %d outs, %d clean, want 15 and 13", len(ak.Outs), ak.Clean)
+	}
+	byHand := ak.ByHand()
+	if len(byHand[Flush]) != 9 || len(byHand[Pair]) != 6 {
+		t.Errorf("AK outs by hand = %v, want 9 flushes and 6 pairs", byHand)
+	}
+	for _, o := range ak.Outs {
+		dirty := o.Card == NewCard(Nine, Hearts) || o.Card == NewCard(Queen, Hearts)
+		if o.Clean == dirty || o.Tie {
+			t.Errorf("out %v = %+v, want clean = %v", o.Card, o, !dirty)
+		}
+	}
+
+	if want := 1 - 435.0/990; math.Abs(ak.Probability-want) > 1e-9 {
+		t.Errorf("Probability = %.4f, want %.4f", ak.Probability, want)
+	}
+	if math.Abs(ak.RuleOf2And4-0.60) > 1e-9 {
+		t.Errorf("RuleOf2And4 = %.2f, want 0.60", ak.RuleOf2And4)
+	}
+	if len(report.Players[1].Outs) != 0 || report.Players[1].Probability != 0 {
+		t.Errorf("the leader has outs %+v", report.Players[1])
+	}
+}
+
+func TestCalculateOutsTurn(t *testing.T) {
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+		{NewCard(Queen, Spades), NewCard(Queen, Clubs)},
+	}
+	board := &Board{Cards: []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Nine, Clubs), NewCard(Three, Diamonds)}}
+
+	report, err := CalculateOuts(holeCards, board)
+	if err != nil {
+		t.Fatalf("CalculateOuts error: %v", err)
+	}
+	ak := report.Players[0]
+	if len(ak.Outs) != 15 {
+		t.Errorf("AK has %d outs on the turn, want 15", len(ak.Outs))
+	}
+	if math.Abs(ak.Probability-15.0/44) > 1e-9 || math.Abs(ak.RuleOf2And4-0.30) > 1e-9 {
+		t.Errorf("Probability = %.4f and RuleOf2And4 = %.2f, want %.4f and 0.30", ak.Probability, ak.RuleOf2And4, 15.0/44)
+	}
+}
+
+func TestCalculateOutsTies(t *testing.T) {
+	// A5 leads A4 on the kicker; a card that pushes both kickers off the
+	// board chops, and a four gives A4 the lead outright
+	holeCards := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Five, Diamonds)},
+		{NewCard(Ace, Clubs), NewCard(Four, Diamonds)},
+	}
+	board := &Board{Cards: []Card{NewCard(King, Hearts), NewCard(Queen, Clubs), NewCard(Eight, Spades), NewCard(Three, Hearts)}}
+
+	report, err := CalculateOuts(holeCards, board)
+	if err != nil {
+		t.Fatalf("CalculateOuts error: %v", err)
+	}
+	if len(report.Leaders) != 1 || report.Leaders[0] != 0 {
+		t.Fatalf("Leaders = %v, want [0]", report.Leaders)
+	}
+
+	outs := make(map[Card]Out)
+	for _, o := range report.Players[1].Outs {
+		outs[o.Card] = o
+	}
+	if o, ok := outs[NewCard(Jack, Clubs)]; !ok || o.Makes != HighCard || !o.Tie || !o.Clean {
+		t.Errorf("jack of clubs for player 1 = %+v, %v, want a clean tie", o, ok)
+	}
+	if o, ok := outs[NewCard(Four, Hearts)]; !ok || o.Makes != Pair || o.Tie || !o.Clean {
+		t.Errorf("four of hearts for player 1 = %+v, %v, want a clean pair", o, ok)
+	}
+	if _, ok := outs[NewCard(Five, Hearts)]; ok {
+		t.Error("five of hearts is an out for player 1")
+	}
+}
+
+func TestCalculateOutsInvalid(t *testing.T) {
+	aces := []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)}
+	kings := []Card{NewCard(King, Spades), NewCard(King, Hearts)}
+	flop := []Card{NewCard(Two, Clubs), NewCard(Three, Clubs), NewCard(Four, Clubs)}
+
+	tests := []struct {
+		name      string
+		holeCards [][]Card
+		board     *Board
+		expected  error
+	}{
+		{"one player", [][]Card{aces}, &Board{Cards: flop}, ErrNotEnoughPlayers},
+		{"preflop", [][]Card{aces, kings}, NewBoard(), ErrInvalidBoardSize},
+		{"river", [][]Card{aces, kings}, &Board{Cards: append(flop[:3:3], NewCard(Five, Clubs), NewCard(Six, Diamonds))}, ErrInvalidBoardSize},
+		{"duplicate", [][]Card{aces, kings}, &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(Three, Clubs), NewCard(Four, Clubs)}}, ErrDuplicateCards},
+		{"no board", [][]Card{aces, kings}, nil, ErrInvalidBoardSize},
+	}
+
+	for _, tt := range tests {
+		if _, err := CalculateOuts(tt.holeCards, tt.board); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: CalculateOuts error = %v, want %v", tt.name, err, tt.expected)
+		}
+	}
+}
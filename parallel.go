@@ -8,60 +8,39 @@ import (
 // GetWinnersParallel returns the winning player(s) using concurrent evaluation.
 // More efficient than GetWinners when there are many players.
 func (g *Game) GetWinnersParallel() ([]*Player, []*Hand, error) {
-	if g.Board.State() != River {
+	if !g.boardComplete(g.Board) {
 		return nil, nil, ErrInvalidBoardState
 	}
-
-	type playerHand struct {
-		player *Player
-		hand   *Hand
-		err    error
+	evaluators := g.rules().Evaluators()
+	if len(evaluators) == 0 {
+		return nil, nil, ErrUnsupportedRanking
 	}
 
-	results := make([]playerHand, len(g.Players))
+	candidates := make([][]*Hand, len(g.Players))
+	errs := make([]error, len(g.Players))
 	var wg sync.WaitGroup
 
 	for i, player := range g.Players {
 		wg.Add(1)
 		go func(idx int, p *Player) {
 			defer wg.Done()
-			best, err := g.GetBestHand(p)
-			results[idx] = playerHand{p, best, err}
+			candidates[idx], errs[idx] = g.candidateHands(p, g.Board)
+			if errs[idx] == nil && len(candidates[idx]) == 0 {
+				errs[idx] = ErrInvalidBoardState
+			}
 		}(i, player)
 	}
 
 	wg.Wait()
 
 	// Check for errors
-	for _, r := range results {
-		if r.err != nil {
-			return nil, nil, r.err
-		}
-	}
-
-	// Find winners
-	var winners []playerHand
-	for _, ph := range results {
-		if len(winners) == 0 {
-			winners = []playerHand{ph}
-			continue
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
 		}
-
-		cmp := ph.hand.Compare(winners[0].hand)
-		if cmp > 0 {
-			winners = []playerHand{ph}
-		} else if cmp == 0 {
-			winners = append(winners, ph)
-		}
-	}
-
-	players := make([]*Player, len(winners))
-	hands := make([]*Hand, len(winners))
-	for i, w := range winners {
-		players[i] = w.player
-		hands[i] = w.hand
 	}
 
+	players, hands := shareWinners(awardShares(evaluators, g.Players, candidates))
 	return players, hands, nil
 }
 
@@ -16,12 +16,21 @@ func NewPlayer(name string) *Player {
 	}
 }
 
-// SetHoleCards sets the player's hole cards (must be exactly 2).
+// SetHoleCards sets the player's Texas Hold'em hole cards (must be exactly
+// 2).
+//
+// Deprecated: A player doesn't know which variant they are playing. Use
+// Game.SetHoleCards, which checks the count against the game's rules.
 func (p *Player) SetHoleCards(cards []Card) error {
-	if len(cards) != 2 {
+	return p.setHoleCards(cards, HoldemRules{}.HoleCards())
+}
+
+// setHoleCards sets the player's hole cards, which must number exactly n.
+func (p *Player) setHoleCards(cards []Card, n int) error {
+	if len(cards) != n {
 		return ErrInvalidHoleCards
 	}
-	p.HoleCards = make([]Card, 2)
+	p.HoleCards = make([]Card, n)
 	copy(p.HoleCards, cards)
 	return nil
 }
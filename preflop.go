@@ -0,0 +1,640 @@
+package goker
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+//go:generate go run ./cmd/preflopgen -o preflop_equity.bin
+
+// Player counts covered by PreflopTable's equities against random hands.
+const (
+	minPreflopPlayers = 2
+	maxPreflopPlayers = 9
+)
+
+// preflopBoards is the number of boards that can be dealt from the 48 cards
+// left once two players have their hole cards.
+const preflopBoards = 1712304
+
+// preflopTableMagic identifies the binary PreflopTable format.
+const preflopTableMagic = "GKPF\x02"
+
+// preflopData is the table generated by cmd/preflopgen. Heads-up results
+// are exact: every board was enumerated for every matchup of hole cards.
+// Equities against 2 or more random hands were sampled at 100,000 deals per
+// hand and player count, a standard error of about 0.16%.
+//
+//go:embed preflop_equity.bin
+var preflopData []byte
+
+// PreflopTable holds preflop all-in results for every matchup of hole
+// cards and each starting hand's equity against random hands, so preflop
+// equities can be looked up instead of simulated. Heads-up results, and
+// equities against one random hand, are exact. Equities against 2 or more
+// random hands are Monte Carlo estimates.
+type PreflopTable struct {
+	// matchups counts the boards won and tied by the first player of each
+	// ordered matchup of hole cards that is the same up to a change of suits,
+	// sorted by key. Only the matchup with the smaller key of the two
+	// seatings is kept; index finds an entry by key.
+	matchups []preflopMatchup
+	index    map[uint32]int
+
+	// win[a][b] and tie[a][b] are the probabilities that hand a beats or
+	// ties hand b, averaged over their non-conflicting combinations.
+	win [numStartingHands][numStartingHands]float64
+	tie [numStartingHands][numStartingHands]float64
+
+	// vsRandom[h][n] is the equity of hand h against n-1 random hands.
+	vsRandom [numStartingHands][maxPreflopPlayers + 1]float64
+
+	// combos[a][b] is the average number of combinations of hand b left once
+	// a combination of hand a has been dealt.
+	combos [numStartingHands][numStartingHands]float64
+}
+
+// preflopMatchup is the number of boards, out of preflopBoards, on which
+// the first player of a matchup wins and ties.
+type preflopMatchup struct {
+	key        uint32 // See matchupKey
+	wins, ties int
+}
+
+// PreflopTableOptions configures GeneratePreflopTable.
+type PreflopTableOptions struct {
+	// RandomSamples is the number of random deals per starting hand and
+	// player count for equities against 2 or more random hands. Equities
+	// against one random hand are derived from the heads-up results.
+	RandomSamples int
+
+	Workers int // If <= 0, uses the number of CPUs
+	Seed    int64
+}
+
+var (
+	preflopOnce  sync.Once
+	preflopTable *PreflopTable
+)
+
+// preflopEquities returns the embedded preflop table, loading it on first use.
+func preflopEquities() *PreflopTable {
+	preflopOnce.Do(func() {
+		preflopTable = &PreflopTable{}
+		if err := preflopTable.UnmarshalBinary(preflopData); err != nil {
+			panic("goker: corrupt embedded preflop table: " + err.Error())
+		}
+	})
+	return preflopTable
+}
+
+// PreflopEquity returns the heads-up all-in equity of starting hand a against
+// starting hand b before the flop (ties count half), from the embedded table.
+func PreflopEquity(a, b StartingHand) float64 {
+	return preflopEquities().Equity(a, b)
+}
+
+// PreflopEquityVsRandom returns the equity of a starting hand all-in
+// preflop against players-1 random hands, from the embedded table. Returns 0
+// unless players is between 2 and 9. Against one random hand the equity is
+// exact; against more it is an estimate from 100,000 random deals, with a
+// standard error of about 0.16%.
+func PreflopEquityVsRandom(h StartingHand, players int) float64 {
+	return preflopEquities().EquityVsRandom(h, players)
+}
+
+// Equity returns the equity of starting hand a against starting hand b.
+func (t *PreflopTable) Equity(a, b StartingHand) float64 {
+	i, j := a.Index(), b.Index()
+	return t.win[i][j] + t.tie[i][j]/2
+}
+
+// WinTie returns the probabilities that starting hand a beats and ties
+// starting hand b.
+func (t *PreflopTable) WinTie(a, b StartingHand) (win, tie float64) {
+	i, j := a.Index(), b.Index()
+	return t.win[i][j], t.tie[i][j]
+}
+
+// EquityVsRandom returns the equity of a starting hand against players-1
+// random hands. Returns 0 unless players is between 2 and 9. Equities for 3
+// or more players are sampled estimates, see PreflopTableOptions.
+func (t *PreflopTable) EquityVsRandom(h StartingHand, players int) float64 {
+	if players < minPreflopPlayers || players > maxPreflopPlayers {
+		return 0
+	}
+	return t.vsRandom[h.Index()][players]
+}
+
+// MatchupResults returns the number of boards, out of every board that can
+// be dealt, on which hole cards a beat and tie hole cards b. ok is false
+// unless a and b are two distinct pairs of cards.
+func (t *PreflopTable) MatchupResults(a, b []Card) (wins, ties, boards int, ok bool) {
+	if len(a) != 2 || len(b) != 2 {
+		return 0, 0, 0, false
+	}
+	sa, okA := newCardSet(a)
+	sb, okB := newCardSet(b)
+	if !okA || !okB || sa&sb != 0 {
+		return 0, 0, 0, false
+	}
+
+	if i, found := t.index[matchupKey(sa, sb)]; found {
+		m := t.matchups[i]
+		return m.wins, m.ties, preflopBoards, true
+	}
+	m := t.matchups[t.index[matchupKey(sb, sa)]]
+	return preflopBoards - m.wins - m.ties, m.ties, preflopBoards, true
+}
+
+// RangeEquity returns the preflop equity of a starting hand against a range,
+// weighting each hand in the range by its combinations left after card
+// removal. Returns 0 if the range is empty.
+func RangeEquity(h StartingHand, r *Range) float64 {
+	t := preflopEquities()
+	a := h.Index()
+	equity, weight := 0.0, 0.0
+	for b, w := range r.weights {
+		if w == 0 {
+			continue
+		}
+		w *= t.combos[a][b]
+		equity += w * (t.win[a][b] + t.tie[a][b]/2)
+		weight += w
+	}
+	if weight == 0 {
+		return 0
+	}
+	return equity / weight
+}
+
+// RangeVsRangeEquity returns the preflop equity of range a against range b.
+// Returns 0 if either range is empty.
+func RangeVsRangeEquity(a, b *Range) float64 {
+	t := preflopEquities()
+	equity, weight := 0.0, 0.0
+	for i, wa := range a.weights {
+		if wa == 0 {
+			continue
+		}
+		wa *= float64(StartingHandFromIndex(i).Combos())
+		for j, wb := range b.weights {
+			if wb == 0 {
+				continue
+			}
+			w := wa * wb * t.combos[i][j]
+			equity += w * (t.win[i][j] + t.tie[i][j]/2)
+			weight += w
+		}
+	}
+	if weight == 0 {
+		return 0
+	}
+	return equity / weight
+}
+
+// GeneratePreflopTable computes a preflop table from scratch. Heads-up
+// results are exact: each canonical river is dealt once, every pair of hole
+// cards is scored on it and the outcome is credited to the pair's matchup,
+// weighted by the boards the river stands for. This takes some minutes on a
+// single core. Equities against 2 or more random hands are sampled.
+func GeneratePreflopTable(opts PreflopTableOptions) *PreflopTable {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	t := &PreflopTable{}
+	rivers, _ := CanonicalBoards(handSize)
+	t.setMatchups(enumeratePreflopMatchups(rivers, workers))
+	hands := AllStartingHands()
+
+	// Each worker fills in whole rows, and the random source for each entry
+	// depends only on the entry, so results don't depend on scheduling.
+	rows := make(chan int, numStartingHands)
+	for a := range hands {
+		rows <- a
+	}
+	close(rows)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range rows {
+				for n := minPreflopPlayers + 1; n <= maxPreflopPlayers; n++ {
+					rng := rand.New(rand.NewSource(opts.Seed*numStartingHands*numStartingHands + int64(a*numStartingHands+n)))
+					t.vsRandom[a][n] = sampleEquityVsRandom(hands[a], n, opts.RandomSamples, rng)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return t
+}
+
+// enumeratePreflopMatchups counts, for every canonical ordered matchup of
+// hole cards, the boards its first player wins and ties on the canonical
+// rivers.
+func enumeratePreflopMatchups(rivers []CanonicalBoard, workers int) []preflopMatchup {
+	var combos []cardSet
+	forEachCardSet(fullCardSet, 2, func(s cardSet) {
+		combos = append(combos, s)
+	})
+	n := len(combos)
+
+	// classes[i*n+j] holds the matchup class of combos i and j seated
+	// either way round, or -1s if they share a card
+	classes := make([][2]int32, n*n)
+	ids := make(map[uint32]int32)
+	var keys []uint32
+	var members []int
+	class := func(a, b cardSet) int32 {
+		key := matchupKey(a, b)
+		id, ok := ids[key]
+		if !ok {
+			id = int32(len(keys))
+			ids[key] = id
+			keys = append(keys, key)
+			members = append(members, 0)
+		}
+		members[id]++
+		return id
+	}
+	for i, a := range combos {
+		for j := i + 1; j < n; j++ {
+			b := combos[j]
+			if a&b != 0 {
+				classes[i*n+j] = [2]int32{-1, -1}
+				continue
+			}
+			classes[i*n+j] = [2]int32{class(a, b), class(b, a)}
+		}
+	}
+
+	jobs := make(chan []CanonicalBoard)
+	results := make(chan [2][]int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wins, ties := make([]int, len(keys)), make([]int, len(keys))
+			scores := make([]handScore, n)
+			valid := make([]int, 0, n)
+			cards := make([]Card, 7)
+			for batch := range jobs {
+				for _, board := range batch {
+					boardSet, _ := newCardSet(board.Cards)
+					copy(cards[2:], board.Cards)
+					valid = valid[:0]
+					for i, combo := range combos {
+						if combo&boardSet != 0 {
+							continue
+						}
+						copy(cards, combo.cards())
+						scores[i] = evaluateCards(cards)
+						valid = append(valid, i)
+					}
+
+					// A win is credited to the winner's seating of the pair
+					// and a tie to both seatings
+					weight := board.Combos
+					for x, i := range valid {
+						score, row := scores[i], classes[i*n:i*n+n]
+						for _, j := range valid[x+1:] {
+							c := row[j]
+							if c[0] < 0 {
+								continue
+							}
+							switch other := scores[j]; {
+							case score > other:
+								wins[c[0]] += weight
+							case score < other:
+								wins[c[1]] += weight
+							default:
+								ties[c[0]] += weight
+								ties[c[1]] += weight
+							}
+						}
+					}
+				}
+			}
+			results <- [2][]int{wins, ties}
+		}()
+	}
+	go func() {
+		for len(rivers) > 0 {
+			size := min(256, len(rivers))
+			jobs <- rivers[:size]
+			rivers = rivers[size:]
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	wins, ties := make([]int, len(keys)), make([]int, len(keys))
+	for r := range results {
+		for i := range keys {
+			wins[i] += r[0][i]
+			ties[i] += r[1][i]
+		}
+	}
+
+	// Every matchup of a class plays out the same, so each has an equal
+	// share of its class's counts
+	matchups := make([]preflopMatchup, 0, len(keys)/2+1)
+	for i, key := range keys {
+		a, b := matchupCards(key)
+		if matchupKey(b, a) < key {
+			continue
+		}
+		matchups = append(matchups, preflopMatchup{
+			key:  key,
+			wins: wins[i] / members[i],
+			ties: ties[i] / members[i],
+		})
+	}
+	return matchups
+}
+
+// setMatchups stores exact matchup results and derives the averages between
+// starting hands and the equities against one random hand from them.
+func (t *PreflopTable) setMatchups(matchups []preflopMatchup) {
+	sort.Slice(matchups, func(i, j int) bool { return matchups[i].key < matchups[j].key })
+	t.matchups = matchups
+	t.index = make(map[uint32]int, len(matchups))
+	t.countCombos()
+
+	// Each matchup stands for as many matchups of the same starting hands
+	// as there are distinct changes of its suits
+	var wins, ties, weights [numStartingHands][numStartingHands]float64
+	add := func(a, b cardSet, count, win, tie int) {
+		ca, cb := a.cards(), b.cards()
+		i := StartingHandFromCards(ca[0], ca[1]).Index()
+		j := StartingHandFromCards(cb[0], cb[1]).Index()
+		wins[i][j] += float64(count * win)
+		ties[i][j] += float64(count * tie)
+		weights[i][j] += float64(count * preflopBoards)
+	}
+	for idx, m := range matchups {
+		t.index[m.key] = idx
+		a, b := matchupCards(m.key)
+		count := matchupOrbit(a, b)
+		add(a, b, count, m.wins, m.ties)
+		if matchupKey(b, a) != m.key {
+			add(b, a, count, preflopBoards-m.wins-m.ties, m.ties)
+		}
+	}
+
+	for a := range numStartingHands {
+		equity, weight := 0.0, 0.0
+		for b := range numStartingHands {
+			t.win[a][b] = wins[a][b] / weights[a][b]
+			t.tie[a][b] = ties[a][b] / weights[a][b]
+			equity += t.combos[a][b] * (t.win[a][b] + t.tie[a][b]/2)
+			weight += t.combos[a][b]
+		}
+		t.vsRandom[a][minPreflopPlayers] = equity / weight
+	}
+}
+
+// matchupKey identifies an ordered matchup of hole cards up to a change of
+// suits: the smallest, over every relabelling of the suits, of the two
+// players' cards as 12-bit pairs of card positions.
+func matchupKey(a, b cardSet) uint32 {
+	key := uint32(math.MaxUint32)
+	for _, perm := range allSuitPermutations {
+		key = min(key, holeCardsKey(a.permute(perm))<<12|holeCardsKey(b.permute(perm)))
+	}
+	return key
+}
+
+// holeCardsKey packs the positions of two cards in a set.
+func holeCardsKey(s cardSet) uint32 {
+	return uint32(bits.TrailingZeros64(uint64(s)))<<6 | uint32(63-bits.LeadingZeros64(uint64(s)))
+}
+
+// matchupCards returns the hole cards a matchup key stands for.
+func matchupCards(key uint32) (a, b cardSet) {
+	set := func(k uint32) cardSet {
+		return 1<<(k>>6&63) | 1<<(k&63)
+	}
+	return set(key >> 12), set(key & (1<<12 - 1))
+}
+
+// matchupOrbit returns the number of distinct matchups a change of suits
+// turns a matchup into, itself included.
+func matchupOrbit(a, b cardSet) int {
+	fixed := 0
+	for _, perm := range allSuitPermutations {
+		if a.permute(perm) == a && b.permute(perm) == b {
+			fixed++
+		}
+	}
+	return len(allSuitPermutations) / fixed
+}
+
+// suitPermutations returns all 24 relabellings of the four suits.
+func suitPermutations() [][4]CardSuit {
+	var perms [][4]CardSuit
+	var perm [4]CardSuit
+	var used [4]bool
+	var build func(i int)
+	build = func(i int) {
+		if i == len(perm) {
+			perms = append(perms, perm)
+			return
+		}
+		for s := Clubs; s <= Spades; s++ {
+			if !used[s] {
+				used[s] = true
+				perm[i] = s
+				build(i + 1)
+				used[s] = false
+			}
+		}
+	}
+	build(0)
+	return perms
+}
+
+// countCombos counts the combinations left between every pair of starting hands.
+func (t *PreflopTable) countCombos() {
+	cards := make([][][]Card, numStartingHands)
+	for i, h := range AllStartingHands() {
+		cards[i] = h.Cards()
+	}
+
+	for a := range cards {
+		for b := range cards {
+			compatible := 0
+			for _, ca := range cards[a] {
+				for _, cb := range cards[b] {
+					if !cardsOverlap(ca, cb) {
+						compatible++
+					}
+				}
+			}
+			t.combos[a][b] = float64(compatible) / float64(len(cards[a]))
+		}
+	}
+}
+
+// MarshalBinary encodes the table: a format header and the number of
+// matchups, then each matchup's key and its first player's wins and ties as
+// little-endian 24-bit integers, then the equities of each starting hand
+// against 2 to 8 random hands as little-endian uint16 fractions of 65535.
+func (t *PreflopTable) MarshalBinary() ([]byte, error) {
+	data := []byte(preflopTableMagic)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(t.matchups)))
+	put24 := func(v int) {
+		data = append(data, byte(v), byte(v>>8), byte(v>>16))
+	}
+	for _, m := range t.matchups {
+		put24(int(m.key))
+		put24(m.wins)
+		put24(m.ties)
+	}
+	for a := 0; a < numStartingHands; a++ {
+		for n := minPreflopPlayers + 1; n <= maxPreflopPlayers; n++ {
+			data = binary.LittleEndian.AppendUint16(data, uint16(t.vsRandom[a][n]*65535+0.5))
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a table encoded by MarshalBinary.
+func (t *PreflopTable) UnmarshalBinary(data []byte) error {
+	header := len(preflopTableMagic) + 4
+	if len(data) < header || string(data[:len(preflopTableMagic)]) != preflopTableMagic {
+		return ErrInvalidPreflopTable
+	}
+	n := int(binary.LittleEndian.Uint32(data[len(preflopTableMagic):]))
+	randomValues := numStartingHands * (maxPreflopPlayers - minPreflopPlayers)
+	if len(data) != header+9*n+2*randomValues {
+		return ErrInvalidPreflopTable
+	}
+
+	data = data[header:]
+	get24 := func() int {
+		v := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+		data = data[3:]
+		return v
+	}
+	// Keys must be canonical and sorted, and between them cover every
+	// ordered matchup of two players' hole cards once
+	matchups := make([]preflopMatchup, n)
+	covered := 0
+	for i := range matchups {
+		key := uint32(get24())
+		wins, ties := get24(), get24()
+		a, b := matchupCards(key)
+		if wins+ties > preflopBoards || (i > 0 && key <= matchups[i-1].key) ||
+			bits.OnesCount64(uint64(a)) != 2 || bits.OnesCount64(uint64(b)) != 2 ||
+			(a|b)&^fullCardSet != 0 || a&b != 0 || matchupKey(a, b) != key {
+			return ErrInvalidPreflopTable
+		}
+		matchups[i] = preflopMatchup{key: key, wins: wins, ties: ties}
+
+		switch reversed := matchupKey(b, a); {
+		case reversed < key:
+			return ErrInvalidPreflopTable
+		case reversed == key:
+			covered += matchupOrbit(a, b)
+		default:
+			covered += 2 * matchupOrbit(a, b)
+		}
+	}
+	if covered != 1326*1225 {
+		return ErrInvalidPreflopTable
+	}
+
+	var table PreflopTable
+	table.setMatchups(matchups)
+	for a := 0; a < numStartingHands; a++ {
+		for p := minPreflopPlayers + 1; p <= maxPreflopPlayers; p++ {
+			table.vsRandom[a][p] = float64(binary.LittleEndian.Uint16(data)) / 65535
+			data = data[2:]
+		}
+	}
+	*t = table
+	return nil
+}
+
+// sampleEquityVsRandom estimates the equity of a starting hand against
+// players-1 random hands.
+func sampleEquityVsRandom(h StartingHand, players, samples int, rng *rand.Rand) float64 {
+	combos := h.Cards()
+	deck := newOrderedDeck().cards
+	dealt := make([]Card, 2*(players-1)+5)
+	hand := make([]Card, 7)
+	points := 0.0
+
+	for s := 0; s < samples; s++ {
+		hole := combos[s%len(combos)]
+
+		// The opponents' hole cards come first, then the board
+		dealCards(deck, dealt, rng, hole)
+		board := dealt[2*(players-1):]
+
+		copy(hand, hole)
+		copy(hand[2:], board)
+		best := evaluateCards(hand)
+		winners := 1
+		for p := 0; p < players-1 && winners > 0; p++ {
+			copy(hand, dealt[2*p:2*p+2])
+			if score := evaluateCards(hand); score > best {
+				winners = 0
+			} else if score == best {
+				winners++
+			}
+		}
+		if winners > 0 {
+			points += 1 / float64(winners)
+		}
+	}
+	return points / float64(samples)
+}
+
+// dealCards fills dst with random cards from deck, skipping cards in
+// excluded, by partially shuffling deck.
+func dealCards(deck, dst []Card, rng *rand.Rand, excluded ...[]Card) {
+	dealt := 0
+	for i := 0; dealt < len(dst); i++ {
+		j := i + rng.Intn(len(deck)-i)
+		deck[i], deck[j] = deck[j], deck[i]
+
+		skip := false
+		for _, cards := range excluded {
+			if cardsOverlap(deck[i:i+1], cards) {
+				skip = true
+			}
+		}
+		if !skip {
+			dst[dealt] = deck[i]
+			dealt++
+		}
+	}
+}
+
+// cardsOverlap returns true if the two sets of cards share a card.
+func cardsOverlap(a, b []Card) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package goker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func mustParseStartingHand(t *testing.T, s string) StartingHand {
+	t.Helper()
+	h, err := ParseStartingHand(s)
+	if err != nil {
+		t.Fatalf("ParseStartingHand(%q) error = %v", s, err)
+	}
+	return h
+}
+
+func mustParseRange(t *testing.T, s string) *Range {
+	t.Helper()
+	r, err := ParseRange(s)
+	if err != nil {
+		t.Fatalf("ParseRange(%q) error = %v", s, err)
+	}
+	return r
+}
+
+func TestPreflopEquity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"AA", "KK", 0.82},
+		{"AKo", "QQ", 0.43},
+		{"AKs", "72o", 0.69},
+		{"JTs", "22", 0.54},
+		{"AA", "AA", 0.50},
+	}
+
+	for _, tt := range tests {
+		a, b := mustParseStartingHand(t, tt.a), mustParseStartingHand(t, tt.b)
+		if got := PreflopEquity(a, b); math.Abs(got-tt.expected) > 0.01 {
+			t.Errorf("PreflopEquity(%s, %s) = %.3f, want about %.2f", tt.a, tt.b, got, tt.expected)
+		}
+		if sum := PreflopEquity(a, b) + PreflopEquity(b, a); math.Abs(sum-1) > 1e-9 {
+			t.Errorf("PreflopEquity(%s, %s) and its reverse sum to %f", tt.a, tt.b, sum)
+		}
+	}
+}
+
+func TestPreflopCardRemoval(t *testing.T) {
+	m := preflopEquities()
+	aa := mustParseStartingHand(t, "AA").Index()
+	aks := mustParseStartingHand(t, "AKs").Index()
+	kk := mustParseStartingHand(t, "KK").Index()
+
+	if m.combos[aa][aa] != 1 || m.combos[aa][kk] != 6 || m.combos[aks][aa] != 3 {
+		t.Errorf("combos AA/AA = %v, AA/KK = %v, AKs/AA = %v, want 1, 6, 3", m.combos[aa][aa], m.combos[aa][kk], m.combos[aks][aa])
+	}
+}
+
+func TestRangeEquity(t *testing.T) {
+	aa := mustParseStartingHand(t, "AA")
+	kk := mustParseStartingHand(t, "KK")
+
+	r := mustParseRange(t, "KK")
+	if got, want := RangeEquity(aa, r), PreflopEquity(aa, kk); math.Abs(got-want) > 1e-9 {
+		t.Errorf("RangeEquity(AA, KK) = %f, want %f", got, want)
+	}
+	if RangeEquity(aa, &Range{}) != 0 {
+		t.Error("RangeEquity() against an empty range should be 0")
+	}
+
+	// A wider range is easier to beat
+	if RangeEquity(kk, mustParseRange(t, "QQ+")) >= RangeEquity(kk, mustParseRange(t, "22+, A2+")) {
+		t.Error("KK should do better against a wide range than against QQ+")
+	}
+
+	strong, weak := mustParseRange(t, "JJ+, AK"), mustParseRange(t, "22-66, 72o")
+	if eq := RangeVsRangeEquity(strong, weak) + RangeVsRangeEquity(weak, strong); math.Abs(eq-1) > 1e-9 {
+		t.Errorf("RangeVsRangeEquity() both ways sums to %f", eq)
+	}
+	if RangeVsRangeEquity(strong, weak) < 0.6 {
+		t.Errorf("RangeVsRangeEquity(JJ+ AK, 22-66 72o) = %f", RangeVsRangeEquity(strong, weak))
+	}
+}
+
+func TestPreflopEquityVsRandom(t *testing.T) {
+	tests := []struct {
+		hand     string
+		players  int
+		expected float64
+	}{
+		{"AA", 2, 0.852},
+		{"72o", 2, 0.346},
+		{"AKs", 2, 0.670},
+		{"AA", 9, 0.347},
+		{"KK", 6, 0.429},
+	}
+
+	for _, tt := range tests {
+		h := mustParseStartingHand(t, tt.hand)
+		if got := PreflopEquityVsRandom(h, tt.players); math.Abs(got-tt.expected) > 0.01 {
+			t.Errorf("PreflopEquityVsRandom(%s, %d) = %.3f, want about %.3f", tt.hand, tt.players, got, tt.expected)
+		}
+	}
+
+	aa := mustParseStartingHand(t, "AA")
+	if PreflopEquityVsRandom(aa, 1) != 0 || PreflopEquityVsRandom(aa, 10) != 0 {
+		t.Error("PreflopEquityVsRandom() outside 2-9 players should be 0")
+	}
+}
+
+func TestPreflopTableBinary(t *testing.T) {
+	data, err := preflopEquities().MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if string(data) != string(preflopData) {
+		t.Error("MarshalBinary() of the embedded table should reproduce it")
+	}
+
+	var table PreflopTable
+	if err := table.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	aks, qq := mustParseStartingHand(t, "AKs"), mustParseStartingHand(t, "QQ")
+	if table.Equity(aks, qq) != PreflopEquity(aks, qq) {
+		t.Errorf("Decoded Equity(AKs, QQ) = %f, want %f", table.Equity(aks, qq), PreflopEquity(aks, qq))
+	}
+
+	if err := table.UnmarshalBinary(data[:100]); err != ErrInvalidPreflopTable {
+		t.Errorf("UnmarshalBinary() of truncated data error = %v, want ErrInvalidPreflopTable", err)
+	}
+	bad := append([]byte("XXXX"), data[4:]...)
+	if err := table.UnmarshalBinary(bad); err != ErrInvalidPreflopTable {
+		t.Errorf("UnmarshalBinary() with a bad header error = %v, want ErrInvalidPreflopTable", err)
+	}
+}
+
+func TestPreflopMatchupResults(t *testing.T) {
+	table := preflopEquities()
+	tests := []struct {
+		a, b     []Card
+		expected float64
+	}{
+		{[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)}, []Card{NewCard(King, Clubs), NewCard(King, Diamonds)}, 0.8126},
+		{[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)}, []Card{NewCard(King, Spades), NewCard(King, Hearts)}, 0.8264},
+		{[]Card{NewCard(Ace, Hearts), NewCard(King, Hearts)}, []Card{NewCard(Queen, Hearts), NewCard(Jack, Hearts)}, 0.6595},
+		{[]Card{NewCard(Queen, Hearts), NewCard(Jack, Hearts)}, []Card{NewCard(Ace, Hearts), NewCard(King, Hearts)}, 0.3405},
+	}
+
+	for _, tt := range tests {
+		wins, ties, boards, ok := table.MatchupResults(tt.a, tt.b)
+		if !ok || boards != preflopBoards {
+			t.Fatalf("MatchupResults(%v, %v) = %d boards, ok %v", tt.a, tt.b, boards, ok)
+		}
+		if equity := (float64(wins) + float64(ties)/2) / float64(boards); math.Abs(equity-tt.expected) > 0.0005 {
+			t.Errorf("MatchupResults(%v, %v) equity = %.4f, want %.4f", tt.a, tt.b, equity, tt.expected)
+		}
+
+		// Swapping the players swaps wins and losses
+		rw, rt, _, _ := table.MatchupResults(tt.b, tt.a)
+		if rt != ties || rw != boards-wins-ties {
+			t.Errorf("MatchupResults(%v, %v) reversed = %d/%d, want %d/%d", tt.b, tt.a, rw, rt, boards-wins-ties, ties)
+		}
+	}
+
+	if _, _, _, ok := table.MatchupResults(
+		[]Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)},
+		[]Card{NewCard(Ace, Spades), NewCard(King, Hearts)},
+	); ok {
+		t.Error("MatchupResults() with a shared card should not be ok")
+	}
+}
+
+func TestSampleEquityVsRandom(t *testing.T) {
+	ako := mustParseStartingHand(t, "AKo")
+	rng := rand.New(rand.NewSource(1))
+	if got := sampleEquityVsRandom(ako, 3, 2000, rng); math.Abs(got-0.50) > 0.04 {
+		t.Errorf("AKo equity against 2 random hands = %.3f, want about 0.50", got)
+	}
+}
+
+func TestEquityCalculatorPreflopLookup(t *testing.T) {
+	ec := NewEquityCalculator(1)
+	holeCards := [][]Card{
+		{NewCard(Ace, Hearts), NewCard(King, Hearts)},
+		{NewCard(Queen, Hearts), NewCard(Jack, Hearts)},
+	}
+
+	results, err := ec.CalculateExact(holeCards, nil, 0)
+	if err != nil {
+		t.Fatalf("CalculateExact error: %v", err)
+	}
+
+	// The suits matter: AKs dominates QJs of its own suit more than the
+	// class average
+	if math.Abs(results[0].Equity-0.6595) > 0.0005 || math.Abs(results[0].Equity+results[1].Equity-1) > 1e-9 {
+		t.Errorf("CalculateExact() preflop = %.4f/%.4f, want 0.6595/0.3405", results[0].Equity, results[1].Equity)
+	}
+	wins, ties, _, _ := preflopEquities().MatchupResults(holeCards[0], holeCards[1])
+	if results[0].Wins != wins || results[0].Ties != ties || results[1].Wins != preflopBoards-wins-ties {
+		t.Errorf("CalculateExact() preflop counts = %+v, want %d wins and %d ties from the table", results, wins, ties)
+	}
+	for _, r := range results {
+		if r.Total != preflopBoards || r.Wins+r.Ties+r.Losses != preflopBoards || r.StdErr != 0 {
+			t.Errorf("CalculateExact() preflop counts = %+v, want every one of %d boards", r, preflopBoards)
+		}
+	}
+
+	// Monte Carlo runs still sample
+	sampled, err := ec.Calculate(holeCards, nil, 1000)
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
+	if sampled[0].Total != 1000 || sampled[0].StdErr == 0 {
+		t.Errorf("Calculate() preflop = %+v, want 1000 sampled runouts", sampled[0])
+	}
+}
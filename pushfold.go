@@ -0,0 +1,272 @@
+package goker
+
+// defaultPushFoldIterations is the number of fictitious play iterations
+// SolvePushFold runs when iterations is not positive.
+const defaultPushFoldIterations = 300
+
+// PushFoldSpot describes a short-stacked preflop situation in which each
+// player either moves all-in or folds.
+type PushFoldSpot struct {
+	// Stacks holds each player's chips before posting, in preflop action
+	// order: the small blind and big blind are the last two players
+	// (heads-up, the button posts the small blind and acts first).
+	Stacks     []int
+	SmallBlind int
+	BigBlind   int
+	Ante       int // Posted by every player
+
+	// Payouts switches from chip EV to ICM EV when set; see ICM.
+	Payouts []float64
+}
+
+// PushFoldResult holds the equilibrium strategies found by SolvePushFold.
+type PushFoldResult struct {
+	// Push[i] is the range player i moves all-in with when folded to. The
+	// big blind, who is never folded to with a decision, has an empty range.
+	Push []*Range
+
+	// Call[i][j] is the range player j calls with after player i moves
+	// all-in and everyone in between folds (nil unless j > i).
+	Call [][]*Range
+}
+
+// SolvePushFold approximates the Nash equilibrium push/fold strategies for a
+// spot by fictitious play: each iteration every player best-responds to the
+// others' average strategies, and the averages converge towards equilibrium.
+// Equities come from the embedded preflop table (see RangeEquity), so no
+// equities are sampled while solving. To keep the game small, once a player
+// calls an all-in everyone behind folds, and card removal is only accounted
+// for in the equity of a hand against a range.
+// If iterations is not positive a default is used.
+func SolvePushFold(spot PushFoldSpot, iterations int) (*PushFoldResult, error) {
+	s, err := newPushFoldSolver(spot)
+	if err != nil {
+		return nil, err
+	}
+	if iterations <= 0 {
+		iterations = defaultPushFoldIterations
+	}
+
+	for t := 1; t <= iterations; t++ {
+		push, call := s.bestResponses()
+		rate := 1 / float64(t)
+		for i := range s.push {
+			s.push[i].mix(push[i], rate)
+			for j := range s.call[i] {
+				if s.call[i][j] != nil {
+					s.call[i][j].mix(call[i][j], rate)
+				}
+			}
+		}
+	}
+
+	return &PushFoldResult{Push: s.push, Call: s.call}, nil
+}
+
+// mix moves the range's weights towards another range by rate.
+func (r *Range) mix(other *Range, rate float64) {
+	for i := range r.weights {
+		r.weights[i] += (other.weights[i] - r.weights[i]) * rate
+	}
+}
+
+// pushFoldSolver holds the strategies being solved and the value of each way
+// the hand can end, which don't depend on the strategies.
+type pushFoldSolver struct {
+	n    int
+	push []*Range
+	call [][]*Range
+
+	walk     []float64     // Everyone folds to the big blind
+	steal    [][]float64   // steal[i]: everyone folds to player i's all-in
+	showdown [][][]float64 // showdown[w][l]: w beats l in an all-in
+
+	// Expected values under the current strategies, see evaluate
+	foldedTo  [][]float64   // foldedTo[k]: the action is folded to player k
+	afterPush [][][]float64 // afterPush[i][j]: i is all-in and it's j's turn
+}
+
+func newPushFoldSolver(spot PushFoldSpot) (*pushFoldSolver, error) {
+	n := len(spot.Stacks)
+	if n < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+	for _, stack := range spot.Stacks {
+		if stack <= 0 {
+			return nil, ErrInvalidStacks
+		}
+	}
+	if spot.SmallBlind < 0 || spot.BigBlind <= 0 || spot.Ante < 0 {
+		return nil, ErrInvalidBlindLevel
+	}
+
+	// Post the antes and blinds
+	behind := make([]int, n)    // Chips left after posting
+	committed := make([]int, n) // Blinds, which count towards an all-in
+	pot := 0
+	for i, stack := range spot.Stacks {
+		ante := min(spot.Ante, stack)
+		behind[i] = stack - ante
+		pot += ante
+	}
+	blinds := []int{spot.SmallBlind, spot.BigBlind}
+	for k, blind := range blinds {
+		i := n - len(blinds) + k
+		posted := min(blind, behind[i])
+		behind[i] -= posted
+		committed[i] = posted
+		pot += posted
+	}
+
+	utility := func(stacks []int) ([]float64, error) {
+		if spot.Payouts != nil {
+			return ICM(stacks, spot.Payouts)
+		}
+		values := make([]float64, len(stacks))
+		for i, s := range stacks {
+			values[i] = float64(s)
+		}
+		return values, nil
+	}
+
+	s := &pushFoldSolver{n: n}
+	var err error
+
+	// A player who wins the pot uncontested collects it
+	winPot := func(winner int) ([]float64, error) {
+		stacks := append([]int(nil), behind...)
+		stacks[winner] += pot
+		return utility(stacks)
+	}
+	if s.walk, err = winPot(n - 1); err != nil {
+		return nil, err
+	}
+
+	s.push = make([]*Range, n)
+	s.call = make([][]*Range, n)
+	s.steal = make([][]float64, n)
+	s.showdown = make([][][]float64, n)
+	for i := range s.showdown {
+		s.showdown[i] = make([][]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		s.push[i] = &Range{}
+		s.call[i] = make([]*Range, n)
+		if i == n-1 {
+			continue
+		}
+		s.push[i] = allHands()
+		if s.steal[i], err = winPot(i); err != nil {
+			return nil, err
+		}
+
+		for j := i + 1; j < n; j++ {
+			s.call[i][j] = allHands()
+
+			// Both players are all-in for the smaller stack, counting their
+			// blinds; the winner takes the loser's chips and the dead money
+			allIn := min(behind[i]+committed[i], behind[j]+committed[j])
+			for _, winner := range []int{i, j} {
+				loser := i + j - winner
+				stacks := append([]int(nil), behind...)
+				stacks[winner] += pot + allIn - committed[loser]
+				stacks[loser] -= allIn - committed[loser]
+				if s.showdown[winner][loser], err = utility(stacks); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// allHands returns a range holding every starting hand.
+func allHands() *Range {
+	r := &Range{}
+	for i := range r.weights {
+		r.weights[i] = 1
+	}
+	return r
+}
+
+// evaluate computes the expected value to every player at each point in the
+// hand under the current strategies.
+func (s *pushFoldSolver) evaluate() {
+	s.afterPush = make([][][]float64, s.n)
+	for i := 0; i < s.n-1; i++ {
+		s.afterPush[i] = make([][]float64, s.n+1)
+		s.afterPush[i][s.n] = s.steal[i]
+		for j := s.n - 1; j > i; j-- {
+			call := s.call[i][j].Fraction()
+			equity := RangeVsRangeEquity(s.push[i], s.call[i][j])
+			showdown := blend(equity, s.showdown[i][j], s.showdown[j][i])
+			s.afterPush[i][j] = blend(call, showdown, s.afterPush[i][j+1])
+		}
+	}
+
+	s.foldedTo = make([][]float64, s.n)
+	s.foldedTo[s.n-1] = s.walk
+	for k := s.n - 2; k >= 0; k-- {
+		push := s.push[k].Fraction()
+		s.foldedTo[k] = blend(push, s.afterPush[k][k+1], s.foldedTo[k+1])
+	}
+}
+
+// blend returns p*a + (1-p)*b.
+func blend(p float64, a, b []float64) []float64 {
+	values := make([]float64, len(a))
+	for i := range values {
+		values[i] = p*a[i] + (1-p)*b[i]
+	}
+	return values
+}
+
+// bestResponses returns every player's best pushing and calling ranges
+// against the current strategies.
+func (s *pushFoldSolver) bestResponses() ([]*Range, [][]*Range) {
+	s.evaluate()
+	hands := AllStartingHands()
+	push := make([]*Range, s.n)
+	call := make([][]*Range, s.n)
+
+	for i := 0; i < s.n; i++ {
+		push[i] = &Range{}
+		call[i] = make([]*Range, s.n)
+		if i == s.n-1 {
+			continue
+		}
+
+		// Calling: j compares calling i's all-in with folding and letting
+		// the players behind decide
+		for j := i + 1; j < s.n; j++ {
+			call[i][j] = &Range{}
+			fold := s.afterPush[i][j+1][j]
+			for _, h := range hands {
+				equity := RangeEquity(h, s.push[i])
+				if equity*s.showdown[j][i][j]+(1-equity)*s.showdown[i][j][j] > fold {
+					call[i][j].Set(h, 1)
+				}
+			}
+		}
+
+		// Pushing: i compares the outcomes of each player behind calling
+		// with folding
+		fold := s.foldedTo[i+1][i]
+		for _, h := range hands {
+			value, reach := 0.0, 1.0
+			for j := i + 1; j < s.n; j++ {
+				calls := reach * s.call[i][j].Fraction()
+				equity := RangeEquity(h, s.call[i][j])
+				value += calls * (equity*s.showdown[i][j][i] + (1-equity)*s.showdown[j][i][i])
+				reach -= calls
+			}
+			value += reach * s.steal[i][i]
+			if value > fold {
+				push[i].Set(h, 1)
+			}
+		}
+	}
+
+	return push, call
+}
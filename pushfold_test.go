@@ -0,0 +1,91 @@
+package goker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolvePushFoldHeadsUp(t *testing.T) {
+	// 10 big blinds deep, the small blind pushes about 58% and the big blind
+	// calls about 37% at equilibrium
+	result, err := SolvePushFold(PushFoldSpot{Stacks: []int{100, 100}, SmallBlind: 5, BigBlind: 10}, 0)
+	if err != nil {
+		t.Fatalf("SolvePushFold() error = %v", err)
+	}
+
+	push, call := result.Push[0], result.Call[0][1]
+	if math.Abs(push.Fraction()-0.58) > 0.04 {
+		t.Errorf("Push range = %.1f%% of hands, want about 58%%", 100*push.Fraction())
+	}
+	if math.Abs(call.Fraction()-0.37) > 0.04 {
+		t.Errorf("Call range = %.1f%% of hands, want about 37%%", 100*call.Fraction())
+	}
+
+	for _, h := range []string{"AA", "A2o", "K2s", "76s"} {
+		if push.Weight(mustParseStartingHand(t, h)) < 0.9 {
+			t.Errorf("%s should be pushed at 10bb: %s", h, push)
+		}
+	}
+	for _, h := range []string{"72o", "32o"} {
+		if push.Contains(mustParseStartingHand(t, h)) || call.Contains(mustParseStartingHand(t, h)) {
+			t.Errorf("%s should never be played at 10bb", h)
+		}
+	}
+	if result.Push[1].Fraction() != 0 {
+		t.Error("The big blind should have no push range")
+	}
+}
+
+func TestSolvePushFoldStackDepth(t *testing.T) {
+	spot := func(stack int) PushFoldSpot {
+		return PushFoldSpot{Stacks: []int{stack, stack}, SmallBlind: 5, BigBlind: 10, Ante: 1}
+	}
+
+	shallow, _ := SolvePushFold(spot(40), 100)
+	deep, _ := SolvePushFold(spot(200), 100)
+	if shallow.Push[0].Fraction() <= deep.Push[0].Fraction() {
+		t.Errorf("Push range at 4bb (%.2f) should be wider than at 20bb (%.2f)", shallow.Push[0].Fraction(), deep.Push[0].Fraction())
+	}
+}
+
+func TestSolvePushFoldICM(t *testing.T) {
+	// On the bubble of a 3-handed sit-and-go, calling off with a medium stack
+	// risks the guaranteed payout, so calling ranges tighten under ICM
+	stacks := []int{3000, 1000, 6000}
+	chips, err := SolvePushFold(PushFoldSpot{Stacks: stacks, SmallBlind: 100, BigBlind: 200}, 0)
+	if err != nil {
+		t.Fatalf("SolvePushFold() error = %v", err)
+	}
+	icm, err := SolvePushFold(PushFoldSpot{Stacks: stacks, SmallBlind: 100, BigBlind: 200, Payouts: []float64{50, 30, 20}}, 0)
+	if err != nil {
+		t.Fatalf("SolvePushFold() with payouts error = %v", err)
+	}
+
+	if len(chips.Push) != 3 || chips.Call[0][1] == nil || chips.Call[1][0] != nil {
+		t.Fatalf("Unexpected result shape: %+v", chips)
+	}
+	if icm.Call[2][0] != nil || icm.Call[0][2] == nil {
+		t.Fatal("Only players behind the pusher should have calling ranges")
+	}
+	if icm.Call[0][1].Fraction() >= chips.Call[0][1].Fraction() {
+		t.Errorf("ICM call range (%.2f) should be tighter than chip EV (%.2f)", icm.Call[0][1].Fraction(), chips.Call[0][1].Fraction())
+	}
+}
+
+func TestSolvePushFoldInvalid(t *testing.T) {
+	tests := []struct {
+		spot PushFoldSpot
+		err  error
+	}{
+		{PushFoldSpot{Stacks: []int{100}, SmallBlind: 5, BigBlind: 10}, ErrNotEnoughPlayers},
+		{PushFoldSpot{Stacks: []int{100, 0}, SmallBlind: 5, BigBlind: 10}, ErrInvalidStacks},
+		{PushFoldSpot{Stacks: []int{100, 100}, SmallBlind: 5}, ErrInvalidBlindLevel},
+		{PushFoldSpot{Stacks: []int{100, 100}, SmallBlind: 5, BigBlind: 10, Payouts: []float64{-1}}, ErrInvalidPayouts},
+	}
+
+	for _, tt := range tests {
+		if _, err := SolvePushFold(tt.spot, 1); err != tt.err {
+			t.Errorf("SolvePushFold(%+v) error = %v, want %v", tt.spot, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package goker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// numStartingHands is the number of distinct preflop hands once suits are
+// ignored: 13 pairs, 78 suited and 78 offsuit hands.
+const numStartingHands = 169
+
+// numHoleCardCombos is the number of 2-card combinations in a deck.
+const numHoleCardCombos = 1326
+
+// StartingHand is a preflop hand class such as "AA", "AKs" or "T9o".
+type StartingHand struct {
+	High   CardRank
+	Low    CardRank
+	Suited bool
+}
+
+// StartingHandFromCards returns the class of two hole cards.
+func StartingHandFromCards(a, b Card) StartingHand {
+	if a.Rank < b.Rank {
+		a, b = b, a
+	}
+	return StartingHand{High: a.Rank, Low: b.Rank, Suited: a.Rank != b.Rank && a.Suit == b.Suit}
+}
+
+// StartingHandFromIndex returns the hand at a position in the 13x13 chart,
+// see StartingHand.Index.
+func StartingHandFromIndex(i int) StartingHand {
+	row, col := CardRank(i/13), CardRank(i%13)
+	switch {
+	case row == col:
+		return StartingHand{High: Ace - row, Low: Ace - row}
+	case row < col:
+		return StartingHand{High: Ace - row, Low: Ace - col, Suited: true}
+	default:
+		return StartingHand{High: Ace - col, Low: Ace - row}
+	}
+}
+
+// AllStartingHands returns the 169 starting hands in chart order.
+func AllStartingHands() []StartingHand {
+	hands := make([]StartingHand, numStartingHands)
+	for i := range hands {
+		hands[i] = StartingHandFromIndex(i)
+	}
+	return hands
+}
+
+// ParseStartingHand parses a hand class like "AA", "AKs" or "T9o".
+func ParseStartingHand(s string) (StartingHand, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return StartingHand{}, ErrInvalidStartingHand
+	}
+	high, ok1 := parseRank(s[0])
+	low, ok2 := parseRank(s[1])
+	if !ok1 || !ok2 {
+		return StartingHand{}, ErrInvalidStartingHand
+	}
+	if high < low {
+		high, low = low, high
+	}
+
+	h := StartingHand{High: high, Low: low}
+	switch {
+	case len(s) == 2 && high == low:
+	case len(s) == 3 && high != low && (s[2] == 's' || s[2] == 'S'):
+		h.Suited = true
+	case len(s) == 3 && high != low && (s[2] == 'o' || s[2] == 'O'):
+	default:
+		return StartingHand{}, ErrInvalidStartingHand
+	}
+	return h, nil
+}
+
+// parseRank parses a rank character such as 'A', 'T' or '7'.
+func parseRank(c byte) (CardRank, bool) {
+	switch c {
+	case 'A', 'a':
+		return Ace, true
+	case 'K', 'k':
+		return King, true
+	case 'Q', 'q':
+		return Queen, true
+	case 'J', 'j':
+		return Jack, true
+	case 'T', 't':
+		return Ten, true
+	}
+	if c >= '2' && c <= '9' {
+		return CardRank(c - '0'), true
+	}
+	return 0, false
+}
+
+// IsPair returns true for pocket pairs.
+func (h StartingHand) IsPair() bool {
+	return h.High == h.Low
+}
+
+// Index returns the hand's position in the 13x13 chart, counting rows from
+// the top: pairs on the diagonal, suited hands above it and offsuit hands
+// below it, with aces in the first row and column.
+func (h StartingHand) Index() int {
+	row, col := int(Ace-h.High), int(Ace-h.Low)
+	if !h.Suited {
+		row, col = col, row
+	}
+	return row*13 + col
+}
+
+// Combos returns the number of ways to deal the hand: 6 for pairs, 4 for
+// suited hands and 12 for offsuit hands.
+func (h StartingHand) Combos() int {
+	switch {
+	case h.IsPair():
+		return 6
+	case h.Suited:
+		return 4
+	default:
+		return 12
+	}
+}
+
+// Cards returns every 2-card combination of the hand.
+func (h StartingHand) Cards() [][]Card {
+	var combos [][]Card
+	for _, s1 := range AllSuits() {
+		for _, s2 := range AllSuits() {
+			switch {
+			case h.IsPair() && s1 >= s2:
+				continue
+			case !h.IsPair() && h.Suited != (s1 == s2):
+				continue
+			}
+			combos = append(combos, []Card{NewCard(h.High, s1), NewCard(h.Low, s2)})
+		}
+	}
+	return combos
+}
+
+// String returns the hand class, e.g. "AKs".
+func (h StartingHand) String() string {
+	s := h.High.String() + h.Low.String()
+	switch {
+	case h.IsPair():
+		return s
+	case h.Suited:
+		return s + "s"
+	default:
+		return s + "o"
+	}
+}
+
+// Range is a weighted set of starting hands. Each hand has a weight between
+// 0 (never held) and 1 (always held); fractional weights describe mixed
+// strategies.
+type Range struct {
+	weights [numStartingHands]float64
+}
+
+// NewRange returns a range holding the given hands with weight 1.
+func NewRange(hands ...StartingHand) *Range {
+	r := &Range{}
+	for _, h := range hands {
+		r.Set(h, 1)
+	}
+	return r
+}
+
+// ParseRange parses a comma-separated range such as "QQ+, AKs, A5s-A2s, KQo".
+// A "+" raises the lower card up to one below the higher card (or pairs up to
+// aces) and a dash spans hands with the same high card or pairs.
+func ParseRange(s string) (*Range, error) {
+	r := &Range{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		hands, err := parseRangePart(part)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hands {
+			r.Set(h, 1)
+		}
+	}
+	return r, nil
+}
+
+// parseRangePart expands one element of a range string.
+func parseRangePart(part string) ([]StartingHand, error) {
+	// Without a suffix, "AK" and "A2+" mean both the suited and offsuit hands
+	if base, plus := strings.CutSuffix(part, "+"); len(base) == 2 && base[0] != base[1] {
+		suffix := ""
+		if plus {
+			suffix = "+"
+		}
+		suited, err := parseRangePart(base + "s" + suffix)
+		if err != nil {
+			return nil, err
+		}
+		offsuit, err := parseRangePart(base + "o" + suffix)
+		if err != nil {
+			return nil, err
+		}
+		return append(suited, offsuit...), nil
+	}
+
+	if from, to, ok := strings.Cut(part, "-"); ok {
+		a, err := ParseStartingHand(from)
+		if err != nil {
+			return nil, err
+		}
+		b, err := ParseStartingHand(to)
+		if err != nil {
+			return nil, err
+		}
+		if a.IsPair() != b.IsPair() || a.Suited != b.Suited || (!a.IsPair() && a.High != b.High) {
+			return nil, ErrInvalidStartingHand
+		}
+		if a.Low < b.Low {
+			a, b = b, a
+		}
+		var hands []StartingHand
+		for low := b.Low; low <= a.Low; low++ {
+			if a.IsPair() {
+				hands = append(hands, StartingHand{High: low, Low: low})
+			} else {
+				hands = append(hands, StartingHand{High: a.High, Low: low, Suited: a.Suited})
+			}
+		}
+		return hands, nil
+	}
+
+	if base, ok := strings.CutSuffix(part, "+"); ok {
+		h, err := ParseStartingHand(base)
+		if err != nil {
+			return nil, err
+		}
+		var hands []StartingHand
+		if h.IsPair() {
+			for r := h.Low; r <= Ace; r++ {
+				hands = append(hands, StartingHand{High: r, Low: r})
+			}
+			return hands, nil
+		}
+		for low := h.Low; low < h.High; low++ {
+			hands = append(hands, StartingHand{High: h.High, Low: low, Suited: h.Suited})
+		}
+		return hands, nil
+	}
+
+	h, err := ParseStartingHand(part)
+	if err != nil {
+		return nil, err
+	}
+	return []StartingHand{h}, nil
+}
+
+// Set sets the weight of a hand, clamped to [0, 1].
+func (r *Range) Set(h StartingHand, weight float64) {
+	r.weights[h.Index()] = max(0, min(1, weight))
+}
+
+// Weight returns the weight of a hand.
+func (r *Range) Weight(h StartingHand) float64 {
+	return r.weights[h.Index()]
+}
+
+// Contains returns true if the hand has any weight in the range.
+func (r *Range) Contains(h StartingHand) bool {
+	return r.weights[h.Index()] > 0
+}
+
+// Hands returns the hands with weight in the range, in chart order.
+func (r *Range) Hands() []StartingHand {
+	var hands []StartingHand
+	for i, w := range r.weights {
+		if w > 0 {
+			hands = append(hands, StartingHandFromIndex(i))
+		}
+	}
+	return hands
+}
+
+// Combos returns the weighted number of hole card combinations in the range.
+func (r *Range) Combos() float64 {
+	total := 0.0
+	for i, w := range r.weights {
+		total += w * float64(StartingHandFromIndex(i).Combos())
+	}
+	return total
+}
+
+// Fraction returns the share of all 1,326 starting combinations in the range.
+func (r *Range) Fraction() float64 {
+	return r.Combos() / numHoleCardCombos
+}
+
+// Chart returns the range as a 13x13 grid with aces in the top row and left
+// column, suited hands above the diagonal and offsuit hands below. Hands
+// always in the range are shown by name, mixed hands in lower case and hands
+// outside the range as dots.
+func (r *Range) Chart() string {
+	var sb strings.Builder
+	for row := 0; row < 13; row++ {
+		cells := make([]string, 13)
+		for col := range cells {
+			i := row*13 + col
+			name := StartingHandFromIndex(i).String()
+			switch w := r.weights[i]; {
+			case w >= 1:
+			case w > 0:
+				name = strings.ToLower(name)
+			default:
+				name = "."
+			}
+			cells[col] = fmt.Sprintf("%-3s", name)
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(cells, " "), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// String returns the hands in the range in chart order, with mixed hands
+// followed by their weight, e.g. "AA, AKs, KQo:0.50".
+func (r *Range) String() string {
+	var parts []string
+	for _, h := range r.Hands() {
+		if w := r.Weight(h); w < 1 {
+			parts = append(parts, fmt.Sprintf("%s:%.2f", h, w))
+		} else {
+			parts = append(parts, h.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,137 @@
+package goker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStartingHand(t *testing.T) {
+	tests := []struct {
+		a, b     Card
+		expected string
+		combos   int
+	}{
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts), "AA", 6},
+		{NewCard(King, Hearts), NewCard(Ace, Hearts), "AKs", 4},
+		{NewCard(Nine, Clubs), NewCard(Ten, Diamonds), "T9o", 12},
+	}
+
+	for _, tt := range tests {
+		h := StartingHandFromCards(tt.a, tt.b)
+		if h.String() != tt.expected {
+			t.Errorf("StartingHandFromCards(%v, %v) = %s, want %s", tt.a, tt.b, h, tt.expected)
+		}
+		if h.Combos() != tt.combos || len(h.Cards()) != tt.combos {
+			t.Errorf("%s has %d combos (%d cards), want %d", h, h.Combos(), len(h.Cards()), tt.combos)
+		}
+		for _, cards := range h.Cards() {
+			if StartingHandFromCards(cards[0], cards[1]) != h {
+				t.Errorf("%s.Cards() includes %v", h, cards)
+			}
+		}
+	}
+}
+
+func TestStartingHandIndex(t *testing.T) {
+	seen := make(map[StartingHand]bool)
+	total := 0
+	for i, h := range AllStartingHands() {
+		if h.Index() != i {
+			t.Errorf("%s.Index() = %d, want %d", h, h.Index(), i)
+		}
+		seen[h] = true
+		total += h.Combos()
+	}
+	if len(seen) != numStartingHands || total != numHoleCardCombos {
+		t.Errorf("%d distinct hands with %d combos, want 169 and 1326", len(seen), total)
+	}
+
+	if got := StartingHandFromIndex(1).String(); got != "AKs" {
+		t.Errorf("StartingHandFromIndex(1) = %s, want AKs", got)
+	}
+	if got := StartingHandFromIndex(13).String(); got != "AKo" {
+		t.Errorf("StartingHandFromIndex(13) = %s, want AKo", got)
+	}
+}
+
+func TestParseStartingHand(t *testing.T) {
+	valid := map[string]string{"AA": "AA", "kas": "AKs", "T9o": "T9o", "29s": "92s"}
+	for s, want := range valid {
+		h, err := ParseStartingHand(s)
+		if err != nil || h.String() != want {
+			t.Errorf("ParseStartingHand(%q) = %v, %v, want %s", s, h, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "A", "AK", "AAs", "AKx", "1Ks", "AKso"} {
+		if _, err := ParseStartingHand(s); err != ErrInvalidStartingHand {
+			t.Errorf("ParseStartingHand(%q) error = %v, want ErrInvalidStartingHand", s, err)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		combos   float64
+	}{
+		{"QQ+", "AA, KK, QQ", 18},
+		{"AK", "AKs, AKo", 16},
+		{"A5s-A3s", "A5s, A4s, A3s", 12},
+		{"66-44, KTs+", "KQs, KJs, KTs, 66, 55, 44", 30},
+		{"T7o+", "T9o, T8o, T7o", 36},
+		{"K9+", "KQs, KJs, KTs, K9s, KQo, KJo, KTo, K9o", 64},
+		{"", "", 0},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRange(tt.input)
+		if err != nil {
+			t.Errorf("ParseRange(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got := r.String(); got != tt.expected {
+			t.Errorf("ParseRange(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+		if r.Combos() != tt.combos {
+			t.Errorf("ParseRange(%q).Combos() = %v, want %v", tt.input, r.Combos(), tt.combos)
+		}
+	}
+
+	for _, s := range []string{"AKs-QJs", "AA-AKs", "XX", "AKs-A2o"} {
+		if _, err := ParseRange(s); err != ErrInvalidStartingHand {
+			t.Errorf("ParseRange(%q) error = %v, want ErrInvalidStartingHand", s, err)
+		}
+	}
+}
+
+func TestRangeWeights(t *testing.T) {
+	aa, _ := ParseStartingHand("AA")
+	kqo, _ := ParseStartingHand("KQo")
+
+	r := NewRange(aa)
+	r.Set(kqo, 0.5)
+	r.Set(StartingHand{High: Two, Low: Two}, 2)
+
+	if r.Weight(kqo) != 0.5 || !r.Contains(kqo) {
+		t.Errorf("Weight(KQo) = %v, want 0.5", r.Weight(kqo))
+	}
+	if r.Combos() != 6+6+6 {
+		t.Errorf("Combos() = %v, want 18", r.Combos())
+	}
+	if got := r.String(); got != "AA, KQo:0.50, 22" {
+		t.Errorf("String() = %q", got)
+	}
+
+	chart := strings.Split(r.Chart(), "\n")
+	if len(chart) != 14 || !strings.HasPrefix(chart[0], "AA  .") {
+		t.Fatalf("Chart() =\n%s", r.Chart())
+	}
+	if !strings.HasPrefix(chart[2], ".   kqo") {
+		t.Errorf("Chart() row 3 = %q, mixed hands should be lower case", chart[2])
+	}
+	if !strings.HasSuffix(chart[12], "22") {
+		t.Errorf("Chart() row 13 = %q", chart[12])
+	}
+}
@@ -0,0 +1,171 @@
+package goker
+
+import "slices"
+
+// Ranking describes which hands win at showdown.
+type Ranking int
+
+const (
+	HighRanking Ranking = iota + 1
+	LowRanking
+	HighLowRanking
+)
+
+func (r Ranking) String() string {
+	switch r {
+	case HighRanking:
+		return "High"
+	case LowRanking:
+		return "Low"
+	case HighLowRanking:
+		return "High-Low"
+	default:
+		return "Unknown"
+	}
+}
+
+// Street describes one round of community cards.
+type Street struct {
+	Cards int  // Number of community cards dealt
+	Burn  bool // Whether a card is burned before dealing
+}
+
+// GameRules describes a poker variant: how the deck is built, how many hole
+// cards each player receives, the sequence of community card streets, how
+// 5-card hands are formed from hole and board cards, and how hands are ranked
+// at showdown.
+// Game is driven entirely by its rules, so new variants plug in by
+// implementing this interface.
+type GameRules interface {
+	// Name returns the variant's display name.
+	Name() string
+
+	// NewDeck returns a new shuffled deck for the variant.
+	NewDeck() *Deck
+
+	// HoleCards returns the number of hole cards dealt to each player.
+	HoleCards() int
+
+	// Streets returns the community card streets in dealing order.
+	Streets() []Street
+
+	// CandidateHands returns every 5-card combination a player may use.
+	// Returns ErrInvalidBoardState if the board is too small to make a hand.
+	CandidateHands(holeCards, board []Card) ([][]Card, error)
+
+	// Evaluators returns the evaluators that split the pot at showdown, one
+	// per equal share, usually the Evaluators of a Ranking.
+	Evaluators() []HandEvaluator
+}
+
+// holdemStreets are the flop, turn and river streets shared by flop games.
+// Rules return copies, so a caller changing its streets can't change every
+// other game's.
+var holdemStreets = []Street{
+	{Cards: 3, Burn: true},
+	{Cards: 1, Burn: true},
+	{Cards: 1, Burn: true},
+}
+
+// HoldemRules implements GameRules for Texas Hold'em.
+type HoldemRules struct{}
+
+// Name returns "Texas Hold'em".
+func (HoldemRules) Name() string {
+	return "Texas Hold'em"
+}
+
+// NewDeck returns a new shuffled 52-card deck.
+func (HoldemRules) NewDeck() *Deck {
+	return NewDeck()
+}
+
+// HoleCards returns 2.
+func (HoldemRules) HoleCards() int {
+	return 2
+}
+
+// Streets returns the flop, turn and river.
+func (HoldemRules) Streets() []Street {
+	return slices.Clone(holdemStreets)
+}
+
+// CandidateHands returns every 5-card combination of hole and board cards.
+func (HoldemRules) CandidateHands(holeCards, board []Card) ([][]Card, error) {
+	if len(board) < 3 {
+		return nil, ErrInvalidBoardState
+	}
+
+	allCards := make([]Card, 0, len(holeCards)+len(board))
+	allCards = append(allCards, holeCards...)
+	allCards = append(allCards, board...)
+
+	hands := make([][]Card, 0, Binomial(len(allCards), 5))
+	for hand := range CombinationsSeq(allCards, 5) {
+		hands = append(hands, hand)
+	}
+	return hands, nil
+}
+
+// Evaluators returns a single high hand evaluator.
+func (HoldemRules) Evaluators() []HandEvaluator {
+	return HighRanking.Evaluators()
+}
+
+// OmahaRules implements GameRules for Omaha, where each player receives 4 hole
+// cards and must use exactly 2 of them with exactly 3 board cards.
+type OmahaRules struct{}
+
+// Name returns "Omaha".
+func (OmahaRules) Name() string {
+	return "Omaha"
+}
+
+// NewDeck returns a new shuffled 52-card deck.
+func (OmahaRules) NewDeck() *Deck {
+	return NewDeck()
+}
+
+// HoleCards returns 4.
+func (OmahaRules) HoleCards() int {
+	return 4
+}
+
+// Streets returns the flop, turn and river.
+func (OmahaRules) Streets() []Street {
+	return slices.Clone(holdemStreets)
+}
+
+// CandidateHands returns every combination of 2 hole cards and 3 board cards.
+func (OmahaRules) CandidateHands(holeCards, board []Card) ([][]Card, error) {
+	if len(board) < 3 {
+		return nil, ErrInvalidBoardState
+	}
+
+	hands := make([][]Card, 0, Binomial(len(holeCards), 2)*Binomial(len(board), 3))
+	var holeBuf [2]Card
+	var boardBuf [3]Card
+	for hole := range CombinationsBuffer(holeCards, 2, holeBuf[:]) {
+		for b := range CombinationsBuffer(board, 3, boardBuf[:]) {
+			hand := make([]Card, 0, 5)
+			hand = append(hand, hole...)
+			hand = append(hand, b...)
+			hands = append(hands, hand)
+		}
+	}
+	return hands, nil
+}
+
+// Evaluators returns a single high hand evaluator.
+func (OmahaRules) Evaluators() []HandEvaluator {
+	return HighRanking.Evaluators()
+}
+
+// boardSize returns the total number of community cards dealt under the rules.
+func boardSize(rules GameRules) int {
+	n := 0
+	for _, street := range rules.Streets() {
+		n += street.Cards
+	}
+	return n
+}
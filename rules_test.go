@@ -0,0 +1,232 @@
+package goker
+
+import "testing"
+
+// testRules is a custom variant used to check that Game is driven by its rules:
+// 3 hole cards, 4 board cards without a burn, a final card after a burn, and
+// a configurable ranking.
+type testRules struct {
+	HoldemRules
+	ranking Ranking
+}
+
+func (testRules) HoleCards() int { return 3 }
+
+func (testRules) Streets() []Street {
+	return []Street{
+		{Cards: 4},
+		{Cards: 1, Burn: true},
+	}
+}
+
+func (r testRules) Evaluators() []HandEvaluator { return r.ranking.Evaluators() }
+
+func TestRankingString(t *testing.T) {
+	tests := []struct {
+		ranking  Ranking
+		expected string
+	}{
+		{HighRanking, "High"},
+		{LowRanking, "Low"},
+		{HighLowRanking, "High-Low"},
+		{Ranking(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ranking.String(); got != tt.expected {
+			t.Errorf("Ranking(%d).String() = %s, want %s", tt.ranking, got, tt.expected)
+		}
+	}
+}
+
+func TestHoldemRules(t *testing.T) {
+	var rules GameRules = HoldemRules{}
+
+	if rules.Name() != "Texas Hold'em" {
+		t.Errorf("Name() = %s, want Texas Hold'em", rules.Name())
+	}
+	if rules.NewDeck().Len() != 52 {
+		t.Errorf("NewDeck() has %d cards, want 52", rules.NewDeck().Len())
+	}
+	if rules.HoleCards() != 2 {
+		t.Errorf("HoleCards() = %d, want 2", rules.HoleCards())
+	}
+	if boardSize(rules) != 5 {
+		t.Errorf("boardSize() = %d, want 5", boardSize(rules))
+	}
+	streets := rules.Streets()
+	streets[0].Cards = 4
+	if boardSize(OmahaRules{}) != 5 || boardSize(rules) != 5 {
+		t.Error("Changing the returned streets should not change the rules")
+	}
+	if evaluators := rules.Evaluators(); len(evaluators) != 1 || evaluators[0] != (HighEvaluator{}) {
+		t.Errorf("Evaluators() = %v, want a single high evaluator", evaluators)
+	}
+
+	_, err := rules.CandidateHands([]Card{NewCard(Ace, Spades), NewCard(King, Spades)}, nil)
+	if err != ErrInvalidBoardState {
+		t.Errorf("CandidateHands() preflop error = %v, want ErrInvalidBoardState", err)
+	}
+}
+
+func TestOmahaRules(t *testing.T) {
+	var rules GameRules = OmahaRules{}
+
+	if rules.Name() != "Omaha" {
+		t.Errorf("Name() = %s, want Omaha", rules.Name())
+	}
+	if rules.NewDeck().Len() != 52 {
+		t.Errorf("NewDeck() has %d cards, want 52", rules.NewDeck().Len())
+	}
+	if rules.HoleCards() != 4 {
+		t.Errorf("HoleCards() = %d, want 4", rules.HoleCards())
+	}
+	if evaluators := rules.Evaluators(); len(evaluators) != 1 || evaluators[0] != (HighEvaluator{}) {
+		t.Errorf("Evaluators() = %v, want a single high evaluator", evaluators)
+	}
+
+	hole := []Card{NewCard(Ace, Spades), NewCard(Ten, Hearts), NewCard(Three, Clubs), NewCard(Four, Diamonds)}
+	board := []Card{
+		NewCard(King, Spades), NewCard(Queen, Spades), NewCard(Jack, Spades),
+		NewCard(Two, Spades), NewCard(Nine, Hearts),
+	}
+
+	hands, err := rules.CandidateHands(hole, board)
+	if err != nil {
+		t.Fatalf("CandidateHands() error = %v", err)
+	}
+	// 4 choose 2 hole * 5 choose 3 board = 60
+	if len(hands) != 60 {
+		t.Errorf("CandidateHands() = %d hands, want 60", len(hands))
+	}
+
+	// Hold'em would play the ace-high flush, but Omaha needs two spades in hand
+	game := &Game{Board: &Board{Cards: board}, Rules: rules}
+	player := NewPlayer("P1")
+	player.HoleCards = hole
+	best, err := game.GetBestHand(player)
+	if err != nil {
+		t.Fatalf("GetBestHand() error = %v", err)
+	}
+	if best.Rank() != Straight {
+		t.Errorf("Omaha best hand = %v, want Straight", best.Rank())
+	}
+
+	_, err = rules.CandidateHands(hole, board[:2])
+	if err != ErrInvalidBoardState {
+		t.Errorf("CandidateHands() error = %v, want ErrInvalidBoardState", err)
+	}
+}
+
+func TestNewGameWithRulesOmaha(t *testing.T) {
+	game := NewGameWithRules(OmahaRules{}, 3)
+
+	for _, player := range game.Players {
+		if len(player.HoleCards) != 4 {
+			t.Errorf("Player has %d hole cards, want 4", len(player.HoleCards))
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := game.DealNextStreet(); err != nil {
+			t.Fatalf("DealNextStreet() error = %v", err)
+		}
+	}
+	if game.Board.State() != River {
+		t.Errorf("State = %v, want River", game.Board.State())
+	}
+
+	winners, hands, err := game.GetWinners()
+	if err != nil {
+		t.Fatalf("GetWinners() error = %v", err)
+	}
+	if len(winners) == 0 || len(winners) != len(hands) {
+		t.Errorf("GetWinners() returned %d winners and %d hands", len(winners), len(hands))
+	}
+}
+
+func TestNewGameWithCustomRules(t *testing.T) {
+	game := NewGameWithRules(testRules{ranking: HighRanking}, 2)
+
+	for _, player := range game.Players {
+		if len(player.HoleCards) != 3 {
+			t.Errorf("Player has %d hole cards, want 3", len(player.HoleCards))
+		}
+	}
+
+	// First street: 4 cards, no burn
+	if err := game.DealNextStreet(); err != nil {
+		t.Fatalf("DealNextStreet() error = %v", err)
+	}
+	if len(game.Board.Cards) != 4 || game.Deck.Len() != 52-6-4 {
+		t.Errorf("After first street board = %d cards, deck = %d cards", len(game.Board.Cards), game.Deck.Len())
+	}
+
+	if _, _, err := game.GetWinners(); err != ErrInvalidBoardState {
+		t.Errorf("GetWinners() before final street error = %v, want ErrInvalidBoardState", err)
+	}
+
+	// Second street: burn and 1 card
+	if err := game.DealNextStreet(); err != nil {
+		t.Fatalf("DealNextStreet() error = %v", err)
+	}
+	if len(game.Board.Cards) != 5 || game.Deck.Len() != 52-6-4-2 {
+		t.Errorf("After second street board = %d cards, deck = %d cards", len(game.Board.Cards), game.Deck.Len())
+	}
+
+	if err := game.DealNextStreet(); err != ErrInvalidBoardState {
+		t.Errorf("DealNextStreet() after final street error = %v, want ErrInvalidBoardState", err)
+	}
+
+	if _, _, err := game.GetWinners(); err != nil {
+		t.Errorf("GetWinners() error = %v", err)
+	}
+}
+
+func TestGameUnsupportedRanking(t *testing.T) {
+	game := NewGameWithRules(testRules{ranking: Ranking(99)}, 2)
+	game.DealNextStreet()
+	game.DealNextStreet()
+
+	if _, _, err := game.GetWinners(); err != ErrUnsupportedRanking {
+		t.Errorf("GetWinners() error = %v, want ErrUnsupportedRanking", err)
+	}
+	if _, _, err := game.GetWinnersParallel(); err != ErrUnsupportedRanking {
+		t.Errorf("GetWinnersParallel() error = %v, want ErrUnsupportedRanking", err)
+	}
+}
+
+func TestGameSetHoleCardsFromRules(t *testing.T) {
+	game := NewGameWithRules(OmahaRules{}, 2)
+	player := game.Players[0]
+	hole := []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(King, Spades), NewCard(King, Hearts)}
+
+	if err := game.SetHoleCards(player, hole); err != nil || len(player.HoleCards) != 4 {
+		t.Errorf("SetHoleCards() with 4 Omaha cards = %v, %v", player.HoleCards, err)
+	}
+	if err := game.SetHoleCards(player, hole[:2]); err != ErrInvalidHoleCards {
+		t.Errorf("SetHoleCards() with 2 Omaha cards error = %v, want ErrInvalidHoleCards", err)
+	}
+	if err := NewGame(2).SetHoleCards(player, hole[:2]); err != nil {
+		t.Errorf("SetHoleCards() with 2 Hold'em cards error = %v", err)
+	}
+}
+
+func TestGameDealStreetsFromRules(t *testing.T) {
+	game := NewGameWithRules(testRules{ranking: HighRanking}, 2)
+
+	if err := game.DealTurn(); err != ErrInvalidBoardState {
+		t.Errorf("DealTurn() before the first street error = %v, want ErrInvalidBoardState", err)
+	}
+	// The first street is 4 cards without a burn, the second 1 card after one
+	if err := game.DealFlop(); err != nil || len(game.Board.Cards) != 4 || game.Deck.Len() != 52-6-4 {
+		t.Errorf("DealFlop() dealt %d cards leaving %d, error = %v", len(game.Board.Cards), game.Deck.Len(), err)
+	}
+	if err := game.DealTurn(); err != nil || len(game.Board.Cards) != 5 || game.Deck.Len() != 52-6-4-2 {
+		t.Errorf("DealTurn() dealt %d cards leaving %d, error = %v", len(game.Board.Cards), game.Deck.Len(), err)
+	}
+	// The rules have no third street
+	if err := game.DealRiver(); err != ErrInvalidBoardState {
+		t.Errorf("DealRiver() error = %v, want ErrInvalidBoardState", err)
+	}
+}
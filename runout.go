@@ -0,0 +1,87 @@
+package goker
+
+// RunItTimes deals n independent runouts of the remaining streets, e.g. to
+// "run it twice" when players are all-in or to play a double-board game.
+// Every runout starts from the current board and draws from the game's deck,
+// so no card appears on more than one runout. The runouts are stored in
+// g.Runouts and returned; the game's own Board is left unchanged.
+func (g *Game) RunItTimes(n int) ([]*Board, error) {
+	if n < 1 {
+		return nil, ErrInvalidRunouts
+	}
+
+	runouts := make([]*Board, n)
+	for i := range runouts {
+		board := g.Board.clone()
+		for !g.boardComplete(board) {
+			if err := g.dealNextStreet(board); err != nil {
+				return nil, err
+			}
+		}
+		runouts[i] = board
+	}
+
+	g.Runouts = runouts
+	return runouts, nil
+}
+
+// SplitPot divides a pot among the winners of each board. When the game has
+// runouts the pot is split evenly across them (odd chips go to the earlier
+// runouts), otherwise the whole pot is played on the game's Board. Each
+// board's share is split evenly among the pot shares the rules define (the
+// high and low halves in hi/lo games, odd chips to the high) that some hand
+// wins, then evenly among each share's winners, with odd chips going to the
+// winner seated first. Only the eligible players can win, which lets
+// side pots be resolved separately; a nil eligible slice means every player.
+// Returns ErrNoEligiblePlayers if nobody can win the pot.
+func (g *Game) SplitPot(amount int, eligible []*Player) (map[*Player]int, error) {
+	if eligible == nil {
+		eligible = g.Players
+	}
+	if len(eligible) == 0 {
+		return nil, ErrNoEligiblePlayers
+	}
+
+	boards := g.Runouts
+	if len(boards) == 0 {
+		boards = []*Board{g.Board}
+	}
+
+	payouts := make(map[*Player]int)
+	for i, board := range boards {
+		boardShare := splitChips(amount, len(boards), i)
+
+		shares, err := g.showdown(board, eligible)
+		if err != nil {
+			return nil, err
+		}
+		awarded := awardedShares(shares)
+		for s, share := range awarded {
+			part := splitChips(boardShare, len(awarded), s)
+			for j, winner := range share.Winners {
+				payouts[winner] += splitChips(part, len(share.Winners), j)
+			}
+		}
+	}
+
+	return payouts, nil
+}
+
+// splitChips returns the i-th of n shares of amount, giving any odd chips to
+// the earliest shares.
+func splitChips(amount, n, i int) int {
+	share := amount / n
+	if i < amount%n {
+		share++
+	}
+	return share
+}
+
+// clone returns a copy of the board that can be dealt to independently.
+func (b *Board) clone() *Board {
+	c := &Board{
+		Cards: make([]Card, len(b.Cards), 5),
+	}
+	copy(c.Cards, b.Cards)
+	return c
+}
@@ -0,0 +1,178 @@
+package goker
+
+import "testing"
+
+func TestRunItTimes(t *testing.T) {
+	game := NewGame(3)
+	game.DealFlop()
+	deckBefore := game.Deck.Len()
+
+	runouts, err := game.RunItTimes(2)
+	if err != nil {
+		t.Fatalf("RunItTimes() error = %v", err)
+	}
+	if len(runouts) != 2 || len(game.Runouts) != 2 {
+		t.Fatalf("RunItTimes(2) returned %d runouts, stored %d", len(runouts), len(game.Runouts))
+	}
+
+	// Each runout burns and deals the turn and river
+	if game.Deck.Len() != deckBefore-8 {
+		t.Errorf("Deck has %d cards, want %d", game.Deck.Len(), deckBefore-8)
+	}
+
+	// The game's own board is untouched
+	if game.Board.State() != Flop {
+		t.Errorf("Board state = %v, want Flop", game.Board.State())
+	}
+
+	seen := make(map[Card]bool)
+	for _, c := range game.Board.Cards {
+		seen[c] = true
+	}
+	for _, p := range game.Players {
+		for _, c := range p.HoleCards {
+			seen[c] = true
+		}
+	}
+
+	for i, board := range runouts {
+		if board.State() != River {
+			t.Errorf("Runout %d state = %v, want River", i, board.State())
+		}
+		for j, c := range game.Board.Cards {
+			if board.Cards[j] != c {
+				t.Errorf("Runout %d does not share the flop", i)
+			}
+		}
+		for _, c := range board.Cards[3:] {
+			if seen[c] {
+				t.Errorf("Card %v reused in runout %d", c, i)
+			}
+			seen[c] = true
+		}
+	}
+}
+
+func TestRunItTimesPreflop(t *testing.T) {
+	game := NewGame(2)
+
+	runouts, err := game.RunItTimes(3)
+	if err != nil {
+		t.Fatalf("RunItTimes() error = %v", err)
+	}
+	for i, board := range runouts {
+		if len(board.Cards) != 5 {
+			t.Errorf("Runout %d has %d cards, want 5", i, len(board.Cards))
+		}
+		if _, _, err := game.GetWinnersOnBoard(board); err != nil {
+			t.Errorf("GetWinnersOnBoard(runout %d) error = %v", i, err)
+		}
+	}
+}
+
+func TestRunItTimesInvalid(t *testing.T) {
+	game := NewGame(2)
+
+	if _, err := game.RunItTimes(0); err != ErrInvalidRunouts {
+		t.Errorf("RunItTimes(0) error = %v, want ErrInvalidRunouts", err)
+	}
+
+	for game.Deck.Len() > 3 {
+		game.Deck.Draw()
+	}
+	if _, err := game.RunItTimes(1); err != ErrEmptyDeck {
+		t.Errorf("RunItTimes() with short deck error = %v, want ErrEmptyDeck", err)
+	}
+}
+
+// newRunoutGame creates a three-handed game where AA wins the first runout and
+// KK hits a set on the second.
+func newRunoutGame() *Game {
+	game := &Game{
+		Deck:    NewDeck(),
+		Board:   NewBoard(),
+		Players: []*Player{NewPlayer("P1"), NewPlayer("P2"), NewPlayer("P3")},
+	}
+	game.SetHoleCards(game.Players[0], []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)})
+	game.SetHoleCards(game.Players[1], []Card{NewCard(King, Spades), NewCard(King, Hearts)})
+	game.SetHoleCards(game.Players[2], []Card{NewCard(Two, Spades), NewCard(Seven, Hearts)})
+	game.Board.SetFlop([]Card{NewCard(Three, Clubs), NewCard(Eight, Diamonds), NewCard(Jack, Clubs)})
+
+	first := game.Board.clone()
+	first.SetTurn(NewCard(Four, Diamonds))
+	first.SetRiver(NewCard(Nine, Spades))
+
+	second := game.Board.clone()
+	second.SetTurn(NewCard(King, Diamonds))
+	second.SetRiver(NewCard(Five, Hearts))
+
+	game.Runouts = []*Board{first, second}
+	return game
+}
+
+func TestSplitPotAcrossRunouts(t *testing.T) {
+	game := newRunoutGame()
+
+	payouts, err := game.SplitPot(101, nil)
+	if err != nil {
+		t.Fatalf("SplitPot() error = %v", err)
+	}
+
+	// Odd chip goes to the first runout
+	if payouts[game.Players[0]] != 51 {
+		t.Errorf("P1 payout = %d, want 51", payouts[game.Players[0]])
+	}
+	if payouts[game.Players[1]] != 50 {
+		t.Errorf("P2 payout = %d, want 50", payouts[game.Players[1]])
+	}
+	if payouts[game.Players[2]] != 0 {
+		t.Errorf("P3 payout = %d, want 0", payouts[game.Players[2]])
+	}
+}
+
+func TestSplitPotEligible(t *testing.T) {
+	game := newRunoutGame()
+
+	// Side pot between P1 and P3 only
+	payouts, err := game.SplitPot(40, []*Player{game.Players[0], game.Players[2]})
+	if err != nil {
+		t.Fatalf("SplitPot() error = %v", err)
+	}
+	if payouts[game.Players[0]] != 40 {
+		t.Errorf("P1 payout = %d, want 40", payouts[game.Players[0]])
+	}
+	if _, ok := payouts[game.Players[1]]; ok {
+		t.Error("P2 should not win a pot they are not eligible for")
+	}
+
+	if payouts, err := game.SplitPot(40, []*Player{}); err != ErrNoEligiblePlayers || payouts != nil {
+		t.Errorf("SplitPot() with nobody eligible = %v, error = %v, want ErrNoEligiblePlayers", payouts, err)
+	}
+}
+
+func TestSplitPotSingleBoard(t *testing.T) {
+	game := &Game{
+		Deck:    NewDeck(),
+		Board:   NewBoard(),
+		Players: []*Player{NewPlayer("P1"), NewPlayer("P2")},
+	}
+	game.SetHoleCards(game.Players[0], []Card{NewCard(Two, Spades), NewCard(Three, Spades)})
+	game.SetHoleCards(game.Players[1], []Card{NewCard(Two, Hearts), NewCard(Three, Hearts)})
+	game.Board.SetFlop([]Card{NewCard(Four, Diamonds), NewCard(Five, Clubs), NewCard(Six, Diamonds)})
+
+	if _, err := game.SplitPot(100, nil); err != ErrInvalidBoardState {
+		t.Errorf("SplitPot() before river error = %v, want ErrInvalidBoardState", err)
+	}
+
+	game.Board.SetTurn(NewCard(King, Clubs))
+	game.Board.SetRiver(NewCard(Queen, Clubs))
+
+	// Both players play the same straight; the odd chip goes to the first seat
+	payouts, err := game.SplitPot(25, nil)
+	if err != nil {
+		t.Fatalf("SplitPot() error = %v", err)
+	}
+	if payouts[game.Players[0]] != 13 || payouts[game.Players[1]] != 12 {
+		t.Errorf("Payouts = %d/%d, want 13/12", payouts[game.Players[0]], payouts[game.Players[1]])
+	}
+}
@@ -0,0 +1,215 @@
+package goker
+
+import "sort"
+
+// HandEvaluator ranks the 5-card hands players make for one share of the pot
+// at showdown. GameRules supply one evaluator per share: a single high
+// evaluator for Hold'em, a high and a low evaluator for hi/lo split games.
+type HandEvaluator interface {
+	// Qualifies reports whether a hand can win the share at all, such as a
+	// low of eight or better.
+	Qualifies(hand *Hand) bool
+
+	// Compare returns a positive number if a beats b, a negative number if b
+	// beats a and 0 if they tie.
+	Compare(a, b *Hand) int
+}
+
+// HighEvaluator awards a share to the highest hand. Every hand qualifies.
+type HighEvaluator struct{}
+
+// Qualifies returns true.
+func (HighEvaluator) Qualifies(*Hand) bool {
+	return true
+}
+
+// Compare compares hands as Hand.Compare does.
+func (HighEvaluator) Compare(a, b *Hand) int {
+	return a.Compare(b)
+}
+
+// LowEvaluator awards a share to the lowest hand by ace-to-five rules: aces
+// are low, straights and flushes don't count and pairs count against a hand,
+// so 5-4-3-2-A is the best low. A nonzero Qualifier is the highest card a
+// qualifying hand may hold, such as Eight for eight-or-better; hands with a
+// pair never qualify then.
+type LowEvaluator struct {
+	Qualifier CardRank
+}
+
+// Qualifies reports whether the hand is unpaired with no card above the
+// qualifier, or true if there is no qualifier.
+func (e LowEvaluator) Qualifies(hand *Hand) bool {
+	if e.Qualifier == 0 {
+		return true
+	}
+	key := lowKey(hand)
+	highest := key >> (lowCategoryShift - 4) & 0xf
+	return key < 1<<lowCategoryShift && highest <= lowValue(e.Qualifier)
+}
+
+// Compare returns a positive number if a is the lower hand.
+func (LowEvaluator) Compare(a, b *Hand) int {
+	return lowKey(b) - lowKey(a)
+}
+
+// lowCategoryShift places a low hand's pairing category above its ranks.
+const lowCategoryShift = 20
+
+// lowKey returns a value that orders hands for ace-to-five low, lower being
+// better: the pairing category (no pair, pair, two pair, trips, full house,
+// quads), then the ranks from the largest group down, highest rank first,
+// with aces counting as 1.
+func lowKey(hand *Hand) int {
+	var counts [Ace + 1]int
+	for _, c := range hand.Cards {
+		counts[lowValue(c.Rank)]++
+	}
+	values := make([]int, 0, handSize)
+	for v := range counts {
+		if counts[v] > 0 {
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] > values[j]
+	})
+
+	var category int
+	switch top := counts[values[0]]; {
+	case top == 4:
+		category = 5
+	case top == 3 && len(values) == 2:
+		category = 4
+	case top == 3:
+		category = 3
+	case top == 2 && len(values) == 3:
+		category = 2
+	case top == 2:
+		category = 1
+	}
+
+	key := category
+	for i := range handSize {
+		key <<= 4
+		if i < len(values) {
+			key |= values[i]
+		}
+	}
+	return key
+}
+
+// Evaluators returns the evaluators that split the pot under the ranking,
+// one per equal share: the high hand for HighRanking, the ace-to-five low for
+// LowRanking and, for HighLowRanking, the high hand and an eight-or-better
+// low. It returns nil for an unknown ranking.
+func (r Ranking) Evaluators() []HandEvaluator {
+	switch r {
+	case HighRanking:
+		return []HandEvaluator{HighEvaluator{}}
+	case LowRanking:
+		return []HandEvaluator{LowEvaluator{}}
+	case HighLowRanking:
+		return []HandEvaluator{HighEvaluator{}, LowEvaluator{Qualifier: Eight}}
+	default:
+		return nil
+	}
+}
+
+// ShowdownShare is one share of the pot at showdown and who wins it.
+type ShowdownShare struct {
+	Evaluator HandEvaluator
+	Winners   []*Player // Empty if no hand qualifies for the share
+	Hands     []*Hand   // Each winner's hand for the share
+}
+
+// Showdown awards each share of the pot the game's rules define on the
+// game's board. A share no hand qualifies for, such as the low half of a
+// hi/lo pot without a qualifying low, has no winners and goes to the winners
+// of the other shares.
+func (g *Game) Showdown() ([]ShowdownShare, error) {
+	return g.showdown(g.Board, g.Players)
+}
+
+// showdown awards the rules' shares among the given players on a board.
+func (g *Game) showdown(board *Board, players []*Player) ([]ShowdownShare, error) {
+	if !g.boardComplete(board) {
+		return nil, ErrInvalidBoardState
+	}
+	evaluators := g.rules().Evaluators()
+	if len(evaluators) == 0 {
+		return nil, ErrUnsupportedRanking
+	}
+
+	candidates := make([][]*Hand, len(players))
+	for i, player := range players {
+		hands, err := g.candidateHands(player, board)
+		if err != nil {
+			return nil, err
+		}
+		if len(hands) == 0 {
+			return nil, ErrInvalidBoardState
+		}
+		candidates[i] = hands
+	}
+	return awardShares(evaluators, players, candidates), nil
+}
+
+// awardShares finds the winners of each evaluator's share given every
+// player's candidate hands.
+func awardShares(evaluators []HandEvaluator, players []*Player, candidates [][]*Hand) []ShowdownShare {
+	shares := make([]ShowdownShare, len(evaluators))
+	for s, evaluator := range evaluators {
+		share := ShowdownShare{Evaluator: evaluator}
+		for i, hands := range candidates {
+			best := bestHandFor(evaluator, hands)
+			if best == nil {
+				continue
+			}
+
+			cmp := 1
+			if len(share.Hands) > 0 {
+				cmp = evaluator.Compare(best, share.Hands[0])
+			}
+			switch {
+			case cmp > 0:
+				share.Winners = []*Player{players[i]}
+				share.Hands = []*Hand{best}
+			case cmp == 0:
+				share.Winners = append(share.Winners, players[i])
+				share.Hands = append(share.Hands, best)
+			}
+		}
+		shares[s] = share
+	}
+	return shares
+}
+
+// bestHandFor returns the best of a player's hands that qualify for an
+// evaluator's share, or nil if none does.
+func bestHandFor(evaluator HandEvaluator, hands []*Hand) *Hand {
+	var best *Hand
+	for _, hand := range hands {
+		if !evaluator.Qualifies(hand) {
+			continue
+		}
+		if best == nil || evaluator.Compare(hand, best) > 0 {
+			best = hand
+		}
+	}
+	return best
+}
+
+// awardedShares returns the shares that have winners.
+func awardedShares(shares []ShowdownShare) []ShowdownShare {
+	awarded := make([]ShowdownShare, 0, len(shares))
+	for _, share := range shares {
+		if len(share.Winners) > 0 {
+			awarded = append(awarded, share)
+		}
+	}
+	return awarded
+}
@@ -0,0 +1,145 @@
+package goker
+
+import "testing"
+
+func mustNewHand(t *testing.T, cards ...Card) *Hand {
+	t.Helper()
+	h, err := NewHand(cards)
+	if err != nil {
+		t.Fatalf("NewHand(%v) error = %v", cards, err)
+	}
+	return h
+}
+
+func TestLowEvaluator(t *testing.T) {
+	c := NewCard
+	wheel := mustNewHand(t, c(Five, Spades), c(Four, Spades), c(Three, Spades), c(Two, Spades), c(Ace, Spades))
+	sixFour := mustNewHand(t, c(Six, Hearts), c(Four, Clubs), c(Three, Diamonds), c(Two, Spades), c(Ace, Hearts))
+	sixFive := mustNewHand(t, c(Six, Clubs), c(Five, Clubs), c(Three, Hearts), c(Two, Hearts), c(Ace, Clubs))
+	eight := mustNewHand(t, c(Eight, Hearts), c(Seven, Clubs), c(Six, Diamonds), c(Five, Spades), c(Three, Hearts))
+	nine := mustNewHand(t, c(Nine, Hearts), c(Four, Hearts), c(Three, Clubs), c(Two, Clubs), c(Ace, Diamonds))
+	pair := mustNewHand(t, c(Two, Diamonds), c(Two, Hearts), c(Three, Spades), c(Four, Diamonds), c(Five, Diamonds))
+
+	// The steel wheel is the best low: its straight and flush don't count
+	order := []*Hand{wheel, sixFour, sixFive, eight, nine, pair}
+	var low LowEvaluator
+	for i := 1; i < len(order); i++ {
+		if low.Compare(order[i-1], order[i]) <= 0 || low.Compare(order[i], order[i-1]) >= 0 {
+			t.Errorf("%v should be a better low than %v", order[i-1], order[i])
+		}
+	}
+	if low.Compare(sixFour, mustNewHand(t, c(Six, Spades), c(Four, Hearts), c(Three, Clubs), c(Two, Diamonds), c(Ace, Diamonds))) != 0 {
+		t.Error("Lows of the same ranks should tie")
+	}
+
+	eightOrBetter := LowEvaluator{Qualifier: Eight}
+	for _, tt := range []struct {
+		hand *Hand
+		want bool
+	}{{wheel, true}, {eight, true}, {nine, false}, {pair, false}} {
+		if got := eightOrBetter.Qualifies(tt.hand); got != tt.want {
+			t.Errorf("Eight-or-better Qualifies(%v) = %v, want %v", tt.hand, got, tt.want)
+		}
+	}
+	if !low.Qualifies(pair) {
+		t.Error("Every hand qualifies without a qualifier")
+	}
+}
+
+// newShowdownGame returns a game under the ranking with fixed hole cards
+// on a complete board.
+func newShowdownGame(ranking Ranking, board []Card, holeCards ...[]Card) *Game {
+	game := &Game{Board: &Board{Cards: board}, Rules: testRules{ranking: ranking}}
+	for i, cards := range holeCards {
+		player := NewPlayer(string(rune('A' + i)))
+		player.HoleCards = cards
+		game.Players = append(game.Players, player)
+	}
+	return game
+}
+
+func TestGameShowdownHighLow(t *testing.T) {
+	c := NewCard
+	board := []Card{c(King, Spades), c(Queen, Diamonds), c(Seven, Clubs), c(Four, Hearts), c(Two, Spades)}
+	game := newShowdownGame(HighLowRanking, board,
+		[]Card{c(Ace, Clubs), c(Three, Diamonds)},
+		[]Card{c(King, Hearts), c(King, Diamonds)},
+		[]Card{c(Eight, Clubs), c(Six, Diamonds)},
+	)
+	low, high, eight := game.Players[0], game.Players[1], game.Players[2]
+
+	shares, err := game.Showdown()
+	if err != nil {
+		t.Fatalf("Showdown() error = %v", err)
+	}
+	if len(shares) != 2 || len(shares[0].Winners) != 1 || shares[0].Winners[0] != high {
+		t.Fatalf("High share = %+v, want the kings", shares[0])
+	}
+	if len(shares[1].Winners) != 1 || shares[1].Winners[0] != low || shares[1].Hands[0].Contains(Eight) {
+		t.Errorf("Low share = %+v, want the 7-4-3-2-A low over the eight low", shares[1])
+	}
+
+	winners, hands, err := game.GetWinners()
+	if err != nil || len(winners) != 2 || winners[0] != high || winners[1] != low || len(hands) != 2 {
+		t.Errorf("GetWinners() = %v, %v, %v, want the kings then the low", winners, hands, err)
+	}
+	parallel, _, err := game.GetWinnersParallel()
+	if err != nil || len(parallel) != 2 || parallel[0] != high || parallel[1] != low {
+		t.Errorf("GetWinnersParallel() = %v, %v, want the kings then the low", parallel, err)
+	}
+
+	// The high half takes the odd chip
+	payouts, err := game.SplitPot(101, nil)
+	if err != nil {
+		t.Fatalf("SplitPot() error = %v", err)
+	}
+	if payouts[high] != 51 || payouts[low] != 50 || payouts[eight] != 0 {
+		t.Errorf("SplitPot(101) = %v, want 51 high and 50 low", payouts)
+	}
+}
+
+func TestGameShowdownNoLow(t *testing.T) {
+	c := NewCard
+	board := []Card{c(King, Spades), c(Queen, Diamonds), c(Jack, Clubs), c(Nine, Hearts), c(Two, Spades)}
+	game := newShowdownGame(HighLowRanking, board,
+		[]Card{c(Ace, Clubs), c(Three, Diamonds)},
+		[]Card{c(King, Hearts), c(King, Diamonds)},
+	)
+
+	shares, err := game.Showdown()
+	if err != nil {
+		t.Fatalf("Showdown() error = %v", err)
+	}
+	if len(shares[1].Winners) != 0 {
+		t.Errorf("Low share winners = %v, want none without an eight-or-better low", shares[1].Winners)
+	}
+
+	// The high hand scoops
+	payouts, err := game.SplitPot(100, nil)
+	if err != nil {
+		t.Fatalf("SplitPot() error = %v", err)
+	}
+	if payouts[game.Players[1]] != 100 {
+		t.Errorf("SplitPot(100) = %v, want all 100 to the kings", payouts)
+	}
+}
+
+func TestGameShowdownLow(t *testing.T) {
+	c := NewCard
+	board := []Card{c(King, Spades), c(Queen, Diamonds), c(Seven, Clubs), c(Four, Hearts), c(Two, Spades)}
+	game := newShowdownGame(LowRanking, board,
+		[]Card{c(Ace, Clubs), c(Three, Diamonds)},
+		[]Card{c(King, Hearts), c(King, Diamonds)},
+	)
+
+	winners, hands, err := game.GetWinners()
+	if err != nil {
+		t.Fatalf("GetWinners() error = %v", err)
+	}
+	if len(winners) != 1 || winners[0] != game.Players[0] {
+		t.Errorf("GetWinners() = %v, want the ace-three low", winners)
+	}
+	if hands[0].Contains(King) || hands[0].Contains(Queen) {
+		t.Errorf("Winning low = %v, want 7-4-3-2-A", hands[0])
+	}
+}
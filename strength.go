@@ -0,0 +1,309 @@
+package goker
+
+import (
+	"context"
+	"iter"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// HandStrength holds the Billings hand strength metrics for a player's hole
+// cards on a board against random opponent hands.
+type HandStrength struct {
+	HS  float64 // Share of the pot against one random hand now, ties counted half
+	HSN float64 // HS raised to the number of opponents
+
+	// PPot is the chance that a hand behind or tied now is ahead by the
+	// river, and NPot the chance that a hand ahead or tied now falls behind,
+	// each against one random hand with ties counted half. Both are 0 on the
+	// river.
+	PPot float64
+	NPot float64
+
+	// EHS is HSN + (1 - HSN) * PPot. EHS2 is the mean over river runouts of
+	// the squared river hand strength against the opponents, which also
+	// rewards hands whose strength varies.
+	EHS  float64
+	EHS2 float64
+
+	Opponents int
+	Runouts   int  // Runouts to the river counted, including suit-symmetric ones
+	Exact     bool // Every runout was enumerated rather than sampled
+	Partial   bool // The run was stopped before it finished
+}
+
+// Showdown states against an opponent, as indexes into potential tables.
+const (
+	strengthAhead = iota
+	strengthTied
+	strengthBehind
+)
+
+// HandStrength computes HS, PPot, NPot, EHS and EHS² for hole cards on a
+// flop, turn or river against opponents random hands. Turns and rivers are
+// enumerated exactly, taking suit symmetries into account; on the flop
+// opts.Simulations random runouts are sampled from opts.Rand, each against
+// every opponent hand. Cards in opts.Dead are removed from the deck and work
+// is shared between opts.Workers (or the calculator's) workers.
+//
+// If ctx ends first, the metrics so far are returned marked Partial along
+// with the context's error.
+func (ec *EquityCalculator) HandStrength(ctx context.Context, holeCards, board []Card, opponents int, opts EquityOptions) (*HandStrength, error) {
+	if opponents < 1 {
+		return nil, ErrNotEnoughPlayers
+	}
+	if n := len(board); n < 3 || n > 5 {
+		return nil, ErrInvalidBoardSize
+	}
+	if err := validateDeal([][]Card{holeCards}, board, opts.Dead); err != nil {
+		return nil, err
+	}
+	exact := len(board) > 3
+	if !exact && opts.Simulations <= 0 {
+		return nil, ErrInvalidSimulations
+	}
+
+	deck := buildRemainingDeck(usedCards([][]Card{holeCards}, board, opts.Dead))
+	run := newStrengthRun(holeCards, board, deck)
+	hs := run.currentStrength()
+	strength := &HandStrength{
+		HS:        hs,
+		HSN:       math.Pow(hs, float64(opponents)),
+		Opponents: opponents,
+		Exact:     exact,
+	}
+	if len(board) == handSize {
+		strength.EHS = strength.HSN
+		strength.EHS2 = strength.HSN * strength.HSN
+		strength.Runouts = 1
+		return strength, nil
+	}
+
+	var batches iter.Seq[runoutBatch]
+	cardsNeeded := handSize - len(board)
+	if exact {
+		symmetries := suitSymmetries([][]Card{holeCards}, board, opts.Dead)
+		batches = func(yield func(runoutBatch) bool) {
+			var batch runoutBatch
+			for runout := range CombinationsBuffer(deck, cardsNeeded, nil) {
+				weight := runoutWeight(runout, symmetries)
+				if weight == 0 {
+					continue
+				}
+				batch.cards = append(batch.cards, runout...)
+				batch.weights = append(batch.weights, weight)
+				if len(batch.weights) == runoutBatchSize {
+					if !yield(batch) {
+						return
+					}
+					batch = runoutBatch{}
+				}
+			}
+			if len(batch.weights) > 0 {
+				yield(batch)
+			}
+		}
+	} else {
+		rng := opts.Rand
+		if rng == nil {
+			rng = rand.New(rand.NewSource(rand.Int63()))
+		}
+		batches = func(yield func(runoutBatch) bool) {
+			shuffled := append([]Card(nil), deck...)
+			for done := 0; done < opts.Simulations; done += runoutBatchSize {
+				n := min(runoutBatchSize, opts.Simulations-done)
+				batch := runoutBatch{cards: make([]Card, n*cardsNeeded), weights: make([]int, n)}
+				for i := range n {
+					dealCards(shuffled, batch.cards[i*cardsNeeded:(i+1)*cardsNeeded], rng)
+					batch.weights[i] = 1
+				}
+				if !yield(batch) {
+					return
+				}
+			}
+		}
+	}
+
+	tally, err := run.potential(ctx, ec.workersFor(opts), batches, opponents)
+	strength.Partial = err != nil
+	strength.Runouts = tally.runouts
+	strength.PPot, strength.NPot = tally.potentials()
+	strength.EHS = strength.HSN + (1-strength.HSN)*strength.PPot
+	if tally.runouts > 0 {
+		strength.EHS2 = tally.squared / float64(tally.runouts)
+	}
+	return strength, err
+}
+
+// strengthRun holds a player's hand and every opponent hand they can face.
+type strengthRun struct {
+	holeCards []Card
+	board     []Card
+	opponents [][]Card
+	sets      []cardSet // Each opponent hand as a set
+	now       []int     // The player's state against each opponent hand now
+}
+
+func newStrengthRun(holeCards, board, deck []Card) *strengthRun {
+	r := &strengthRun{holeCards: holeCards, board: board}
+	for combo := range CombinationsSeq(deck, 2) {
+		s, _ := newCardSet(combo)
+		r.opponents = append(r.opponents, combo)
+		r.sets = append(r.sets, s)
+	}
+	return r
+}
+
+// currentStrength fills in the player's state against each opponent hand on
+// the board as it is and returns their hand strength.
+func (r *strengthRun) currentStrength() float64 {
+	ours := evaluateCards(append(append([]Card(nil), r.holeCards...), r.board...))
+	theirs := append(make([]Card, 2), r.board...)
+	r.now = make([]int, len(r.opponents))
+	ahead, tied := 0, 0
+	for i, combo := range r.opponents {
+		copy(theirs, combo)
+		r.now[i] = compareScores(ours, evaluateCards(theirs))
+		switch r.now[i] {
+		case strengthAhead:
+			ahead++
+		case strengthTied:
+			tied++
+		}
+	}
+	return (float64(ahead) + float64(tied)/2) / float64(len(r.opponents))
+}
+
+// compareScores returns the player's state with a score against another.
+func compareScores(ours, theirs handScore) int {
+	switch {
+	case ours > theirs:
+		return strengthAhead
+	case ours == theirs:
+		return strengthTied
+	default:
+		return strengthBehind
+	}
+}
+
+// potential runs the batches of runouts through the workers, comparing the
+// player against every opponent hand on each runout.
+func (r *strengthRun) potential(ctx context.Context, workers int, batches iter.Seq[runoutBatch], opponents int) (*potentialTally, error) {
+	jobs := make(chan runoutBatch)
+	tallies := make(chan *potentialTally)
+
+	// complete is only read once every worker is done with the jobs
+	complete := false
+	go func() {
+		defer close(jobs)
+		for batch := range batches {
+			select {
+			case jobs <- batch:
+			case <-ctx.Done():
+				return
+			}
+		}
+		complete = true
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work := r.newWorker(opponents)
+			for batch := range jobs {
+				tally := &potentialTally{}
+				work(batch, tally)
+				tallies <- tally
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(tallies)
+	}()
+
+	tally := &potentialTally{}
+	for t := range tallies {
+		tally.add(t)
+	}
+	if !complete {
+		return tally, context.Cause(ctx)
+	}
+	return tally, nil
+}
+
+// newWorker returns a function that tallies a batch of runouts.
+func (r *strengthRun) newWorker(opponents int) func(runoutBatch, *potentialTally) {
+	cardsNeeded := handSize - len(r.board)
+	ours := append(append(append([]Card(nil), r.holeCards...), r.board...), make([]Card, cardsNeeded)...)
+	theirs := append(append(make([]Card, 2), r.board...), make([]Card, cardsNeeded)...)
+
+	return func(batch runoutBatch, tally *potentialTally) {
+		for i, weight := range batch.weights {
+			runout := batch.cards[i*cardsNeeded : (i+1)*cardsNeeded]
+			runoutSet, _ := newCardSet(runout)
+			copy(ours[len(ours)-cardsNeeded:], runout)
+			copy(theirs[len(theirs)-cardsNeeded:], runout)
+			score := evaluateCards(ours)
+
+			ahead, tied, faced := 0, 0, 0
+			for j, combo := range r.opponents {
+				if r.sets[j]&runoutSet != 0 {
+					continue
+				}
+				copy(theirs, combo)
+				final := compareScores(score, evaluateCards(theirs))
+				tally.hp[r.now[j]][final] += weight
+				tally.hpTotal[r.now[j]] += weight
+				switch final {
+				case strengthAhead:
+					ahead++
+				case strengthTied:
+					tied++
+				}
+				faced++
+			}
+
+			hs := (float64(ahead) + float64(tied)/2) / float64(faced)
+			tally.squared += float64(weight) * math.Pow(hs, 2*float64(opponents))
+			tally.runouts += weight
+		}
+	}
+}
+
+// potentialTally counts, for each state now, the player's state on the
+// river against opponent hands over weighted runouts.
+type potentialTally struct {
+	hp      [3][3]int
+	hpTotal [3]int
+	squared float64 // Weighted sum of the squared river hand strength
+	runouts int
+}
+
+func (t *potentialTally) add(other *potentialTally) {
+	for i := range t.hp {
+		for j := range t.hp[i] {
+			t.hp[i][j] += other.hp[i][j]
+		}
+		t.hpTotal[i] += other.hpTotal[i]
+	}
+	t.squared += other.squared
+	t.runouts += other.runouts
+}
+
+// potentials returns PPot and NPot as Billings et al. define them.
+func (t *potentialTally) potentials() (ppot, npot float64) {
+	hp, total := t.hp, t.hpTotal
+	if d := total[strengthBehind] + total[strengthTied]; d > 0 {
+		ppot = (float64(hp[strengthBehind][strengthAhead]) + float64(hp[strengthBehind][strengthTied])/2 +
+			float64(hp[strengthTied][strengthAhead])/2) / float64(d)
+	}
+	if d := total[strengthAhead] + total[strengthTied]; d > 0 {
+		npot = (float64(hp[strengthAhead][strengthBehind]) + float64(hp[strengthTied][strengthBehind])/2 +
+			float64(hp[strengthAhead][strengthTied])/2) / float64(d)
+	}
+	return ppot, npot
+}
@@ -0,0 +1,187 @@
+package goker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForceStrength computes HS, PPot, NPot and EHS² against one opponent by
+// enumerating every opponent hand and runout.
+func bruteForceStrength(holeCards, board []Card) (hs, ppot, npot, ehs2 float64) {
+	used, _ := newCardSet(append(append([]Card(nil), holeCards...), board...))
+	deck := (fullCardSet &^ used).cards()
+	state := func(ours, theirs handScore) int { return compareScores(ours, theirs) }
+	score := func(hole, runout []Card) handScore {
+		return evaluateCards(append(append(append([]Card(nil), hole...), board...), runout...))
+	}
+
+	var hp [3][3]float64
+	var total [3]float64
+	var now [3]float64
+	for opp := range CombinationsSeq(deck, 2) {
+		now[state(score(holeCards, nil), score(opp, nil))]++
+	}
+	hs = (now[strengthAhead] + now[strengthTied]/2) / (now[0] + now[1] + now[2])
+
+	runouts := 0
+	for runout := range CombinationsSeq(deck, handSize-len(board)) {
+		var river [3]float64
+		for opp := range CombinationsSeq(deck, 2) {
+			if cardsOverlap(opp, runout) {
+				continue
+			}
+			before := state(score(holeCards, nil), score(opp, nil))
+			after := state(score(holeCards, runout), score(opp, runout))
+			hp[before][after]++
+			total[before]++
+			river[after]++
+		}
+		h := (river[strengthAhead] + river[strengthTied]/2) / (river[0] + river[1] + river[2])
+		ehs2 += h * h
+		runouts++
+	}
+	ppot = (hp[strengthBehind][strengthAhead] + hp[strengthBehind][strengthTied]/2 + hp[strengthTied][strengthAhead]/2) /
+		(total[strengthBehind] + total[strengthTied])
+	npot = (hp[strengthAhead][strengthBehind] + hp[strengthTied][strengthBehind]/2 + hp[strengthAhead][strengthTied]/2) /
+		(total[strengthAhead] + total[strengthTied])
+	return hs, ppot, npot, ehs2 / float64(runouts)
+}
+
+func TestHandStrengthTurn(t *testing.T) {
+	// Hearts and diamonds can be swapped, so half the rivers stand for two
+	hole := []Card{NewCard(Ace, Hearts), NewCard(Ace, Diamonds)}
+	board := []Card{NewCard(King, Spades), NewCard(Seven, Clubs), NewCard(Two, Clubs), NewCard(Three, Spades)}
+
+	ec := NewEquityCalculator(2)
+	s, err := ec.HandStrength(context.Background(), hole, board, 1, EquityOptions{})
+	if err != nil {
+		t.Fatalf("HandStrength error: %v", err)
+	}
+	if !s.Exact || s.Partial || s.Runouts != 46 {
+		t.Errorf("HandStrength Exact = %v, Partial = %v, Runouts = %d, want an exact run over 46 rivers", s.Exact, s.Partial, s.Runouts)
+	}
+
+	hs, ppot, npot, ehs2 := bruteForceStrength(hole, board)
+	for _, m := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"HS", s.HS, hs},
+		{"PPot", s.PPot, ppot},
+		{"NPot", s.NPot, npot},
+		{"EHS", s.EHS, hs + (1-hs)*ppot},
+		{"EHS2", s.EHS2, ehs2},
+	} {
+		if math.Abs(m.got-m.want) > 1e-9 {
+			t.Errorf("%s = %.6f, want %.6f", m.name, m.got, m.want)
+		}
+	}
+	if s.PPot <= 0 || s.NPot <= 0 {
+		t.Errorf("PPot = %.4f and NPot = %.4f, want both positive", s.PPot, s.NPot)
+	}
+}
+
+func TestHandStrengthRiver(t *testing.T) {
+	hole := []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts)}
+	board := []Card{NewCard(Ace, Diamonds), NewCard(King, Clubs), NewCard(Seven, Hearts), NewCard(Four, Spades), NewCard(Two, Diamonds)}
+
+	ec := NewEquityCalculator(1)
+	s, err := ec.HandStrength(context.Background(), hole, board, 2, EquityOptions{})
+	if err != nil {
+		t.Fatalf("HandStrength error: %v", err)
+	}
+	// Only a wheel, 35, beats top set
+	if want := 1 - 16.0/990; math.Abs(s.HS-want) > 1e-9 {
+		t.Errorf("HS = %.6f, want %.6f", s.HS, want)
+	}
+	if math.Abs(s.HSN-s.HS*s.HS) > 1e-12 || s.EHS != s.HSN || math.Abs(s.EHS2-s.HSN*s.HSN) > 1e-12 {
+		t.Errorf("river metrics = %+v, want HSN = HS², EHS = HSN and EHS2 = HSN²", s)
+	}
+	if s.PPot != 0 || s.NPot != 0 {
+		t.Errorf("river PPot = %.4f, NPot = %.4f, want 0", s.PPot, s.NPot)
+	}
+}
+
+func TestHandStrengthFlopSampled(t *testing.T) {
+	hole := []Card{NewCard(Ace, Hearts), NewCard(King, Hearts)}
+	board := []Card{NewCard(Queen, Hearts), NewCard(Seven, Hearts), NewCard(Two, Clubs)}
+
+	run := func(workers int) *HandStrength {
+		ec := NewEquityCalculator(workers)
+		s, err := ec.HandStrength(context.Background(), hole, board, 1, EquityOptions{Rand: rand.New(rand.NewSource(3)), Simulations: 600})
+		if err != nil {
+			t.Fatalf("HandStrength error: %v", err)
+		}
+		return s
+	}
+	s := run(1)
+	if s.Exact || s.Runouts != 600 {
+		t.Errorf("HandStrength Exact = %v, Runouts = %d, want 600 sampled runouts", s.Exact, s.Runouts)
+	}
+	if other := run(3); *other != *s {
+		t.Errorf("seeded runs differ by workers: %+v and %+v", s, other)
+	}
+
+	if testing.Short() {
+		return
+	}
+	hs, ppot, npot, ehs2 := bruteForceStrength(hole, board)
+	if math.Abs(s.HS-hs) > 1e-9 {
+		t.Errorf("HS = %.4f, want %.4f", s.HS, hs)
+	}
+	for _, m := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"PPot", s.PPot, ppot},
+		{"NPot", s.NPot, npot},
+		{"EHS2", s.EHS2, ehs2},
+	} {
+		if math.Abs(m.got-m.want) > 0.03 {
+			t.Errorf("%s = %.4f, want about %.4f", m.name, m.got, m.want)
+		}
+	}
+}
+
+func TestHandStrengthCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	hole := []Card{NewCard(Ace, Hearts), NewCard(King, Hearts)}
+	board := []Card{NewCard(Queen, Hearts), NewCard(Seven, Hearts), NewCard(Two, Clubs)}
+	s, err := NewEquityCalculator(2).HandStrength(ctx, hole, board, 1, EquityOptions{Simulations: 100000})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("HandStrength error = %v, want context.Canceled", err)
+	}
+	if s == nil || !s.Partial || s.Runouts >= 100000 {
+		t.Errorf("HandStrength = %+v, want partial results", s)
+	}
+}
+
+func TestHandStrengthInvalid(t *testing.T) {
+	ec := NewEquityCalculator(1)
+	ctx := context.Background()
+	hole := []Card{NewCard(Ace, Hearts), NewCard(King, Hearts)}
+	flop := []Card{NewCard(Queen, Hearts), NewCard(Seven, Hearts), NewCard(Two, Clubs)}
+
+	tests := []struct {
+		name      string
+		board     []Card
+		opponents int
+		opts      EquityOptions
+		expected  error
+	}{
+		{"no opponents", flop, 0, EquityOptions{Simulations: 10}, ErrNotEnoughPlayers},
+		{"preflop", nil, 1, EquityOptions{Simulations: 10}, ErrInvalidBoardSize},
+		{"no flop simulations", flop, 1, EquityOptions{}, ErrInvalidSimulations},
+		{"dead card on board", flop, 1, EquityOptions{Simulations: 10, Dead: []Card{NewCard(Two, Clubs)}}, ErrDuplicateCards},
+	}
+	for _, tt := range tests {
+		if _, err := ec.HandStrength(ctx, hole, tt.board, tt.opponents, tt.opts); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: HandStrength error = %v, want %v", tt.name, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package goker
+
+import "fmt"
+
+// AnteType describes who posts the ante.
+type AnteType int
+
+const (
+	NoAnte AnteType = iota
+	StandardAnte
+	BigBlindAnte
+	ButtonAnte
+)
+
+func (a AnteType) String() string {
+	switch a {
+	case NoAnte:
+		return "No Ante"
+	case StandardAnte:
+		return "Ante"
+	case BigBlindAnte:
+		return "Big Blind Ante"
+	case ButtonAnte:
+		return "Button Ante"
+	default:
+		return "Unknown"
+	}
+}
+
+// StraddleType describes which player, if any, posts a straddle.
+type StraddleType int
+
+const (
+	NoStraddle StraddleType = iota
+	UTGStraddle
+	MississippiStraddle
+)
+
+func (s StraddleType) String() string {
+	switch s {
+	case NoStraddle:
+		return "No Straddle"
+	case UTGStraddle:
+		return "UTG Straddle"
+	case MississippiStraddle:
+		return "Mississippi Straddle"
+	default:
+		return "Unknown"
+	}
+}
+
+// TableOptions configures the forced bets posted at the start of each hand.
+type TableOptions struct {
+	// AnteType selects who posts the ante. StandardAnte charges every player
+	// Ante; BigBlindAnte and ButtonAnte charge Ante once, to the big blind or
+	// the button, on behalf of the table.
+	AnteType AnteType
+	Ante     int
+
+	// Straddle adds a live blind of twice the big blind. A UTG straddle is
+	// posted by the player left of the big blind, a Mississippi straddle by
+	// the button. Straddles are not posted heads-up.
+	Straddle StraddleType
+
+	// BombPot makes every hand a bomb pot: each player antes BombPotAnte,
+	// no blinds are posted and the hand starts on the flop.
+	BombPot     bool
+	BombPotAnte int
+}
+
+// Seat holds a player's place at a table and their chips.
+type Seat struct {
+	Player *Player
+	Stack  int
+	Bet    int  // Live chips committed on the current street
+	AllIn  bool // No chips left behind
+}
+
+// Table manages seats, the button and the forced bets that start each hand.
+type Table struct {
+	Seats      []*Seat // Empty seats are nil
+	Button     int
+	SmallBlind int
+	BigBlind   int
+	Options    TableOptions
+
+	// Hand state, set by StartHand
+	Game           *Game
+	Pot            int // Dead money (antes) not yet matched to a bet
+	CurrentBet     int
+	SmallBlindSeat int // -1 if no small blind was posted
+	BigBlindSeat   int // -1 if no big blind was posted
+	StraddleSeat   int // -1 if no straddle was posted
+	ToAct          int // Seat of the first player to act
+}
+
+// NewTable creates an empty table with the given number of seats and blinds.
+func NewTable(numSeats, smallBlind, bigBlind int, options TableOptions) *Table {
+	return &Table{
+		Seats:          make([]*Seat, numSeats),
+		SmallBlind:     smallBlind,
+		BigBlind:       bigBlind,
+		Options:        options,
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
+		StraddleSeat:   -1,
+		ToAct:          -1,
+	}
+}
+
+// Sit seats a player with the given stack.
+func (t *Table) Sit(seat int, player *Player, stack int) error {
+	if seat < 0 || seat >= len(t.Seats) {
+		return ErrInvalidSeat
+	}
+	if t.Seats[seat] != nil {
+		return ErrSeatTaken
+	}
+	t.Seats[seat] = &Seat{Player: player, Stack: stack}
+	return nil
+}
+
+// Leave removes the player from a seat and returns the seat they left.
+func (t *Table) Leave(seat int) (*Seat, error) {
+	if seat < 0 || seat >= len(t.Seats) || t.Seats[seat] == nil {
+		return nil, ErrInvalidSeat
+	}
+	s := t.Seats[seat]
+	t.Seats[seat] = nil
+	return s, nil
+}
+
+// NumPlayers returns the number of occupied seats.
+func (t *Table) NumPlayers() int {
+	n := 0
+	for _, s := range t.Seats {
+		if s != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// activeSeats returns the seats that can play a hand (occupied with chips),
+// in order starting with the first seat after from.
+func (t *Table) activeSeats(from int) []int {
+	seats := make([]int, 0, len(t.Seats))
+	for i := 1; i <= len(t.Seats); i++ {
+		idx := (from + i) % len(t.Seats)
+		if s := t.Seats[idx]; s != nil && s.Stack > 0 {
+			seats = append(seats, idx)
+		}
+	}
+	return seats
+}
+
+// nextActiveSeat returns the first seat after from that can play a hand, or -1.
+func (t *Table) nextActiveSeat(from int) int {
+	seats := t.activeSeats(from)
+	if len(seats) == 0 {
+		return -1
+	}
+	return seats[0]
+}
+
+// MoveButton moves the button to the next seat that can play a hand.
+func (t *Table) MoveButton() {
+	if next := t.nextActiveSeat(t.Button); next >= 0 {
+		t.Button = next
+	}
+}
+
+// buttonSeat returns the seat the button plays from in a hand: its own seat
+// if that player can play, otherwise the next seat that can, or -1. Players
+// who leave or bust can leave the button on an empty seat.
+func (t *Table) buttonSeat() int {
+	if s := t.Seats[t.Button]; s != nil && s.Stack > 0 {
+		return t.Button
+	}
+	return t.nextActiveSeat(t.Button)
+}
+
+// NextBigBlindSeat returns the seat due to post the big blind in the next
+// hand, or -1 if fewer than 2 players have chips. If a hand has been started
+// the button is assumed to move on before the next one; otherwise it moves
+// off an empty or busted seat as StartHand would move it.
+func (t *Table) NextBigBlindSeat() int {
+	button := t.buttonSeat()
+	if t.Game != nil {
+		button = t.nextActiveSeat(t.Button)
+	}
+	if button < 0 {
+		return -1
+	}
+	order := t.activeSeats(button)
+	switch {
+	case len(order) < 2:
+		return -1
+	case len(order) == 2:
+		// Heads-up the button posts the small blind
+		return order[0]
+	default:
+		return order[1]
+	}
+}
+
+// StartHand deals a new hand to every seated player with chips and posts the
+// forced bets configured by the table's options. The button is not moved
+// unless its seat is empty or busted, in which case it moves on to the next
+// player with chips.
+func (t *Table) StartHand() error {
+	order := t.activeSeats(t.Button)
+	if len(order) < 2 {
+		return ErrNotEnoughPlayers
+	}
+	if button := t.buttonSeat(); button != t.Button {
+		t.Button = button
+		order = t.activeSeats(button)
+	}
+
+	t.Pot = 0
+	t.CurrentBet = 0
+	t.SmallBlindSeat, t.BigBlindSeat, t.StraddleSeat, t.ToAct = -1, -1, -1, -1
+	for _, s := range t.Seats {
+		if s != nil {
+			s.Bet = 0
+			s.AllIn = false
+		}
+	}
+
+	players := make([]*Player, len(order))
+	for i, idx := range order {
+		players[i] = t.Seats[idx].Player
+	}
+	t.Game = &Game{
+		Deck:    NewDeck(),
+		Board:   NewBoard(),
+		Players: players,
+	}
+	if err := t.Game.DealHoleCards(); err != nil {
+		return err
+	}
+
+	if t.Options.BombPot {
+		for _, idx := range order {
+			t.Pot += t.post(idx, t.Options.BombPotAnte)
+		}
+		t.ToAct = t.firstToAct(order)
+		return t.Game.DealFlop()
+	}
+
+	t.postAntes(order)
+	t.postBlinds(order)
+	t.ToAct = t.firstToAct(t.ActionOrder())
+	return nil
+}
+
+// postAntes posts the antes for the hand.
+func (t *Table) postAntes(order []int) {
+	switch t.Options.AnteType {
+	case StandardAnte:
+		for _, idx := range order {
+			t.Pot += t.post(idx, t.Options.Ante)
+		}
+	case ButtonAnte:
+		t.Pot += t.post(t.Button, t.Options.Ante)
+	}
+	// The big blind ante is posted after the big blind, see postBlinds
+}
+
+// postBlinds posts the small blind, big blind and any straddle.
+func (t *Table) postBlinds(order []int) {
+	headsUp := len(order) == 2
+	if headsUp {
+		// The button posts the small blind heads-up
+		t.SmallBlindSeat = t.Button
+		t.BigBlindSeat = order[0]
+	} else {
+		t.SmallBlindSeat = order[0]
+		t.BigBlindSeat = order[1]
+	}
+
+	t.Seats[t.SmallBlindSeat].Bet += t.post(t.SmallBlindSeat, t.SmallBlind)
+	t.Seats[t.BigBlindSeat].Bet += t.post(t.BigBlindSeat, t.BigBlind)
+	if t.Options.AnteType == BigBlindAnte {
+		t.Pot += t.post(t.BigBlindSeat, t.Options.Ante)
+	}
+	t.CurrentBet = t.BigBlind
+
+	if headsUp {
+		return
+	}
+
+	switch t.Options.Straddle {
+	case UTGStraddle:
+		t.StraddleSeat = t.nextActiveSeat(t.BigBlindSeat)
+	case MississippiStraddle:
+		t.StraddleSeat = t.Button
+	}
+	if t.StraddleSeat >= 0 {
+		t.Seats[t.StraddleSeat].Bet += t.post(t.StraddleSeat, 2*t.BigBlind)
+		t.CurrentBet = 2 * t.BigBlind
+	}
+}
+
+// post takes up to amount from a seat's stack and returns the chips posted.
+func (t *Table) post(seat, amount int) int {
+	s := t.Seats[seat]
+	if amount > s.Stack {
+		amount = s.Stack
+	}
+	s.Stack -= amount
+	if s.Stack == 0 {
+		s.AllIn = true
+	}
+	return amount
+}
+
+// ActionOrder returns the seats in the order they act on the current street.
+// Preflop, action starts left of the big blind (or left of a UTG straddle)
+// and a Mississippi straddler acts last. After the flop, and in bomb pots,
+// action starts left of the button.
+func (t *Table) ActionOrder() []int {
+	if t.Game == nil {
+		return nil
+	}
+	if t.Game.Board.State() != Preflop || t.BigBlindSeat < 0 {
+		return t.dealtSeats(t.Button)
+	}
+
+	last := t.BigBlindSeat
+	if t.Options.Straddle == UTGStraddle && t.StraddleSeat >= 0 {
+		last = t.StraddleSeat
+	}
+	order := t.dealtSeats(last)
+
+	if t.Options.Straddle == MississippiStraddle && t.StraddleSeat >= 0 {
+		for i, idx := range order {
+			if idx == t.StraddleSeat {
+				order = append(order[:i:i], order[i+1:]...)
+				break
+			}
+		}
+		order = append(order, t.StraddleSeat)
+	}
+	return order
+}
+
+// dealtSeats returns the seats dealt into the current hand, starting after from.
+func (t *Table) dealtSeats(from int) []int {
+	inHand := make(map[*Player]bool, len(t.Game.Players))
+	for _, p := range t.Game.Players {
+		inHand[p] = true
+	}
+
+	seats := make([]int, 0, len(t.Game.Players))
+	for i := 1; i <= len(t.Seats); i++ {
+		idx := (from + i) % len(t.Seats)
+		if s := t.Seats[idx]; s != nil && inHand[s.Player] {
+			seats = append(seats, idx)
+		}
+	}
+	return seats
+}
+
+// firstToAct returns the first seat in order that still has chips to act with.
+func (t *Table) firstToAct(order []int) int {
+	for _, idx := range order {
+		if !t.Seats[idx].AllIn {
+			return idx
+		}
+	}
+	return -1
+}
+
+// TotalPot returns the dead money plus every live bet on the current street.
+func (t *Table) TotalPot() int {
+	total := t.Pot
+	for _, s := range t.Seats {
+		if s != nil {
+			total += s.Bet
+		}
+	}
+	return total
+}
+
+// String returns a string representation of the table.
+func (t *Table) String() string {
+	return fmt.Sprintf("<Table: %d/%d seats, blinds %d/%d>", t.NumPlayers(), len(t.Seats), t.SmallBlind, t.BigBlind)
+}
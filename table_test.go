@@ -0,0 +1,333 @@
+package goker
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newTestTable seats numPlayers players with 1000 chips each at a 5/10 table
+// with the button in seat 0.
+func newTestTable(t *testing.T, numPlayers int, options TableOptions) *Table {
+	t.Helper()
+	table := NewTable(numPlayers, 5, 10, options)
+	for i := 0; i < numPlayers; i++ {
+		if err := table.Sit(i, NewPlayer(fmt.Sprintf("P%d", i+1)), 1000); err != nil {
+			t.Fatalf("Sit() error = %v", err)
+		}
+	}
+	return table
+}
+
+func startTestHand(t *testing.T, table *Table) {
+	t.Helper()
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand() error = %v", err)
+	}
+}
+
+func equalSeats(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTableSit(t *testing.T) {
+	table := NewTable(2, 5, 10, TableOptions{})
+
+	if err := table.Sit(0, NewPlayer("P1"), 100); err != nil {
+		t.Errorf("Sit() error = %v", err)
+	}
+	if err := table.Sit(0, NewPlayer("P2"), 100); err != ErrSeatTaken {
+		t.Errorf("Sit() in taken seat error = %v, want ErrSeatTaken", err)
+	}
+	if err := table.Sit(2, NewPlayer("P2"), 100); err != ErrInvalidSeat {
+		t.Errorf("Sit() out of range error = %v, want ErrInvalidSeat", err)
+	}
+	if table.NumPlayers() != 1 {
+		t.Errorf("NumPlayers() = %d, want 1", table.NumPlayers())
+	}
+
+	seat, err := table.Leave(0)
+	if err != nil || seat.Player.Name != "P1" {
+		t.Errorf("Leave() = %v, %v", seat, err)
+	}
+	if _, err := table.Leave(0); err != ErrInvalidSeat {
+		t.Errorf("Leave() empty seat error = %v, want ErrInvalidSeat", err)
+	}
+}
+
+func TestTableStartHandNotEnoughPlayers(t *testing.T) {
+	table := NewTable(6, 5, 10, TableOptions{})
+	table.Sit(0, NewPlayer("P1"), 1000)
+	table.Sit(3, NewPlayer("P2"), 0)
+
+	if err := table.StartHand(); err != ErrNotEnoughPlayers {
+		t.Errorf("StartHand() error = %v, want ErrNotEnoughPlayers", err)
+	}
+}
+
+func TestTableBlinds(t *testing.T) {
+	table := newTestTable(t, 6, TableOptions{})
+	startTestHand(t, table)
+
+	if table.SmallBlindSeat != 1 || table.BigBlindSeat != 2 {
+		t.Errorf("Blinds posted by seats %d/%d, want 1/2", table.SmallBlindSeat, table.BigBlindSeat)
+	}
+	if table.Seats[1].Stack != 995 || table.Seats[2].Stack != 990 {
+		t.Errorf("Stacks after blinds = %d/%d, want 995/990", table.Seats[1].Stack, table.Seats[2].Stack)
+	}
+	if table.TotalPot() != 15 || table.CurrentBet != 10 {
+		t.Errorf("TotalPot() = %d, CurrentBet = %d, want 15, 10", table.TotalPot(), table.CurrentBet)
+	}
+	if table.ToAct != 3 {
+		t.Errorf("ToAct = %d, want 3", table.ToAct)
+	}
+	if got := table.ActionOrder(); !equalSeats(got, []int{3, 4, 5, 0, 1, 2}) {
+		t.Errorf("ActionOrder() = %v, want [3 4 5 0 1 2]", got)
+	}
+	for _, p := range table.Game.Players {
+		if len(p.HoleCards) != 2 {
+			t.Errorf("%v has %d hole cards, want 2", p, len(p.HoleCards))
+		}
+	}
+
+	// Postflop action starts left of the button
+	table.Game.DealFlop()
+	if got := table.ActionOrder(); !equalSeats(got, []int{1, 2, 3, 4, 5, 0}) {
+		t.Errorf("Postflop ActionOrder() = %v, want [1 2 3 4 5 0]", got)
+	}
+}
+
+func TestTableBlindsHeadsUp(t *testing.T) {
+	table := newTestTable(t, 2, TableOptions{Straddle: UTGStraddle})
+	startTestHand(t, table)
+
+	if table.SmallBlindSeat != 0 || table.BigBlindSeat != 1 {
+		t.Errorf("Heads-up blinds posted by seats %d/%d, want 0/1", table.SmallBlindSeat, table.BigBlindSeat)
+	}
+	if table.StraddleSeat != -1 {
+		t.Errorf("StraddleSeat = %d, straddles are not posted heads-up", table.StraddleSeat)
+	}
+	if table.ToAct != 0 {
+		t.Errorf("ToAct = %d, the button acts first preflop heads-up", table.ToAct)
+	}
+}
+
+func TestTableAntes(t *testing.T) {
+	tests := []struct {
+		options TableOptions
+		pot     int
+		stacks  []int
+	}{
+		{TableOptions{AnteType: StandardAnte, Ante: 1}, 4 + 15, []int{999, 994, 989, 999}},
+		{TableOptions{AnteType: BigBlindAnte, Ante: 10}, 10 + 15, []int{1000, 995, 980, 1000}},
+		{TableOptions{AnteType: ButtonAnte, Ante: 10}, 10 + 15, []int{990, 995, 990, 1000}},
+	}
+
+	for _, tt := range tests {
+		table := newTestTable(t, 4, tt.options)
+		startTestHand(t, table)
+
+		if table.TotalPot() != tt.pot {
+			t.Errorf("%v: TotalPot() = %d, want %d", tt.options.AnteType, table.TotalPot(), tt.pot)
+		}
+		for i, want := range tt.stacks {
+			if table.Seats[i].Stack != want {
+				t.Errorf("%v: seat %d stack = %d, want %d", tt.options.AnteType, i, table.Seats[i].Stack, want)
+			}
+		}
+	}
+}
+
+func TestTableUTGStraddle(t *testing.T) {
+	table := newTestTable(t, 6, TableOptions{Straddle: UTGStraddle})
+	startTestHand(t, table)
+
+	if table.StraddleSeat != 3 || table.Seats[3].Bet != 20 {
+		t.Errorf("Straddle posted by seat %d for %d, want seat 3 for 20", table.StraddleSeat, table.Seats[3].Bet)
+	}
+	if table.CurrentBet != 20 {
+		t.Errorf("CurrentBet = %d, want 20", table.CurrentBet)
+	}
+	if table.ToAct != 4 {
+		t.Errorf("ToAct = %d, want 4", table.ToAct)
+	}
+	if got := table.ActionOrder(); !equalSeats(got, []int{4, 5, 0, 1, 2, 3}) {
+		t.Errorf("ActionOrder() = %v, want [4 5 0 1 2 3]", got)
+	}
+}
+
+func TestTableMississippiStraddle(t *testing.T) {
+	table := newTestTable(t, 6, TableOptions{Straddle: MississippiStraddle})
+	startTestHand(t, table)
+
+	if table.StraddleSeat != 0 || table.Seats[0].Bet != 20 {
+		t.Errorf("Straddle posted by seat %d for %d, want seat 0 for 20", table.StraddleSeat, table.Seats[0].Bet)
+	}
+	if table.ToAct != 3 {
+		t.Errorf("ToAct = %d, want 3", table.ToAct)
+	}
+	// The button straddler acts last, after the blinds
+	if got := table.ActionOrder(); !equalSeats(got, []int{3, 4, 5, 1, 2, 0}) {
+		t.Errorf("ActionOrder() = %v, want [3 4 5 1 2 0]", got)
+	}
+
+	threeHanded := newTestTable(t, 3, TableOptions{Straddle: MississippiStraddle})
+	startTestHand(t, threeHanded)
+	if got := threeHanded.ActionOrder(); !equalSeats(got, []int{1, 2, 0}) {
+		t.Errorf("3-handed ActionOrder() = %v, want [1 2 0]", got)
+	}
+}
+
+func TestTableBombPot(t *testing.T) {
+	table := newTestTable(t, 6, TableOptions{BombPot: true, BombPotAnte: 50})
+	startTestHand(t, table)
+
+	if table.Game.Board.State() != Flop {
+		t.Errorf("Bomb pot board state = %v, want Flop", table.Game.Board.State())
+	}
+	if table.Pot != 300 || table.CurrentBet != 0 {
+		t.Errorf("Pot = %d, CurrentBet = %d, want 300, 0", table.Pot, table.CurrentBet)
+	}
+	if table.SmallBlindSeat != -1 || table.BigBlindSeat != -1 {
+		t.Error("Bomb pots should not post blinds")
+	}
+	if table.ToAct != 1 {
+		t.Errorf("ToAct = %d, want 1", table.ToAct)
+	}
+	for i, s := range table.Seats {
+		if s.Stack != 950 {
+			t.Errorf("Seat %d stack = %d, want 950", i, s.Stack)
+		}
+	}
+}
+
+func TestTableShortStackAllIn(t *testing.T) {
+	table := NewTable(4, 5, 10, TableOptions{})
+	table.Sit(0, NewPlayer("P1"), 1000)
+	table.Sit(1, NewPlayer("P2"), 1000)
+	table.Sit(2, NewPlayer("P3"), 4)
+	table.Sit(3, NewPlayer("P4"), 1000)
+
+	// The short stack in seat 2 is all-in posting the big blind
+	startTestHand(t, table)
+
+	if table.Seats[2].Bet != 4 || !table.Seats[2].AllIn {
+		t.Errorf("Short big blind bet = %d, all-in = %v, want 4, true", table.Seats[2].Bet, table.Seats[2].AllIn)
+	}
+	if table.ToAct != 3 {
+		t.Errorf("ToAct = %d, want 3", table.ToAct)
+	}
+}
+
+func TestTableMoveButton(t *testing.T) {
+	table := NewTable(6, 5, 10, TableOptions{})
+	table.Sit(0, NewPlayer("P1"), 1000)
+	table.Sit(3, NewPlayer("P2"), 1000)
+	table.Sit(4, NewPlayer("P3"), 0)
+
+	table.MoveButton()
+	if table.Button != 3 {
+		t.Errorf("Button = %d, want 3", table.Button)
+	}
+	// Busted players are skipped
+	table.MoveButton()
+	if table.Button != 0 {
+		t.Errorf("Button = %d, want 0", table.Button)
+	}
+}
+
+func TestTableButtonOnEmptySeat(t *testing.T) {
+	// Players only in seats 2 and 4, with the button on empty seat 0
+	table := NewTable(6, 5, 10, TableOptions{})
+	table.Sit(2, NewPlayer("P1"), 1000)
+	table.Sit(4, NewPlayer("P2"), 1000)
+
+	if got := table.NextBigBlindSeat(); got != 4 {
+		t.Errorf("NextBigBlindSeat() = %d, want 4", got)
+	}
+	startTestHand(t, table)
+	if table.Button != 2 || table.SmallBlindSeat != 2 || table.BigBlindSeat != 4 {
+		t.Errorf("Button %d, blinds posted by seats %d/%d, want button 2 posting 2/4", table.Button, table.SmallBlindSeat, table.BigBlindSeat)
+	}
+}
+
+func TestTableButtonForcedBets(t *testing.T) {
+	// Seat 0 is empty and seat 3 busted; a button left on either moves to
+	// the next player with chips, who posts the button's forced bets
+	tests := []struct {
+		button, want, sb, bb int
+	}{
+		{0, 1, 4, 5},
+		{3, 4, 5, 1},
+	}
+
+	for _, tt := range tests {
+		for _, options := range []TableOptions{{AnteType: ButtonAnte, Ante: 10}, {Straddle: MississippiStraddle}} {
+			table := NewTable(6, 5, 10, options)
+			table.Sit(1, NewPlayer("P1"), 1000)
+			table.Sit(3, NewPlayer("P2"), 0)
+			table.Sit(4, NewPlayer("P3"), 1000)
+			table.Sit(5, NewPlayer("P4"), 1000)
+			table.Button = tt.button
+			startTestHand(t, table)
+
+			if table.Button != tt.want || table.SmallBlindSeat != tt.sb || table.BigBlindSeat != tt.bb {
+				t.Errorf("Button from seat %d: button %d, blinds %d/%d, want button %d, blinds %d/%d",
+					tt.button, table.Button, table.SmallBlindSeat, table.BigBlindSeat, tt.want, tt.sb, tt.bb)
+			}
+			if options.AnteType == ButtonAnte && (table.Pot != 10 || table.Seats[tt.want].Stack != 990) {
+				t.Errorf("Button ante from seat %d: pot %d, seat %d stack %d, want 10 posted by seat %d",
+					tt.button, table.Pot, tt.want, table.Seats[tt.want].Stack, tt.want)
+			}
+			if options.Straddle == MississippiStraddle && (table.StraddleSeat != tt.want || table.Seats[tt.want].Bet != 20) {
+				t.Errorf("Mississippi straddle from seat %d: posted by seat %d, want seat %d for 20",
+					tt.button, table.StraddleSeat, tt.want)
+			}
+		}
+	}
+}
+
+func TestTableOptionStrings(t *testing.T) {
+	anteTests := []struct {
+		ante     AnteType
+		expected string
+	}{
+		{NoAnte, "No Ante"},
+		{StandardAnte, "Ante"},
+		{BigBlindAnte, "Big Blind Ante"},
+		{ButtonAnte, "Button Ante"},
+		{AnteType(99), "Unknown"},
+	}
+	for _, tt := range anteTests {
+		if got := tt.ante.String(); got != tt.expected {
+			t.Errorf("AnteType(%d).String() = %s, want %s", tt.ante, got, tt.expected)
+		}
+	}
+
+	straddleTests := []struct {
+		straddle StraddleType
+		expected string
+	}{
+		{NoStraddle, "No Straddle"},
+		{UTGStraddle, "UTG Straddle"},
+		{MississippiStraddle, "Mississippi Straddle"},
+		{StraddleType(99), "Unknown"},
+	}
+	for _, tt := range straddleTests {
+		if got := tt.straddle.String(); got != tt.expected {
+			t.Errorf("StraddleType(%d).String() = %s, want %s", tt.straddle, got, tt.expected)
+		}
+	}
+
+	table := newTestTable(t, 2, TableOptions{})
+	if got := table.String(); got != "<Table: 2/2 seats, blinds 5/10>" {
+		t.Errorf("Table.String() = %s", got)
+	}
+}
@@ -0,0 +1,280 @@
+package goker
+
+// BoardPairing describes the rank groups on a board.
+type BoardPairing int
+
+const (
+	BoardUnpaired  BoardPairing = iota + 1 // Every rank differs
+	BoardPaired                            // One pair
+	BoardTwoPaired                         // Two pairs
+	BoardTrips                             // Three of a rank
+	BoardFullHouse                         // Three of a rank and a pair
+	BoardQuads                             // Four of a rank
+)
+
+func (p BoardPairing) String() string {
+	switch p {
+	case BoardUnpaired:
+		return "Unpaired"
+	case BoardPaired:
+		return "Paired"
+	case BoardTwoPaired:
+		return "Two Paired"
+	case BoardTrips:
+		return "Trips"
+	case BoardFullHouse:
+		return "Full House"
+	case BoardQuads:
+		return "Quads"
+	default:
+		return "Unknown"
+	}
+}
+
+// BoardSuits describes how a board's suits are spread.
+type BoardSuits int
+
+const (
+	BoardRainbow    BoardSuits = iota + 1 // No two cards share a suit
+	BoardTwoTone                          // At most two cards of a suit
+	BoardThreeFlush                       // Three cards of a suit, but not all of them
+	BoardFourFlush                        // Four cards of a suit, but not all of them
+	BoardMonotone                         // Every card the same suit
+)
+
+func (s BoardSuits) String() string {
+	switch s {
+	case BoardRainbow:
+		return "Rainbow"
+	case BoardTwoTone:
+		return "Two-Tone"
+	case BoardThreeFlush:
+		return "Three-Flush"
+	case BoardFourFlush:
+		return "Four-Flush"
+	case BoardMonotone:
+		return "Monotone"
+	default:
+		return "Unknown"
+	}
+}
+
+// BoardHeight classes a board by its highest card.
+type BoardHeight int
+
+const (
+	LowBoard     BoardHeight = iota + 1 // Eight high or lower
+	MiddleBoard                         // Nine to jack high
+	HighBoard                           // Queen or king high
+	AceHighBoard                        // Ace high
+)
+
+func (h BoardHeight) String() string {
+	switch h {
+	case LowBoard:
+		return "Low"
+	case MiddleBoard:
+		return "Middle"
+	case HighBoard:
+		return "High"
+	case AceHighBoard:
+		return "Ace High"
+	default:
+		return "Unknown"
+	}
+}
+
+// BoardTexture describes a flop, turn or river for postflop strategy.
+type BoardTexture struct {
+	Pairing     BoardPairing
+	Suits       BoardSuits
+	SuitedCards int // Most cards of one suit
+
+	// Connectedness is the most board ranks within the five ranks of one
+	// straight; three or more make a straight possible. Straights counts
+	// the straights, by high card, a player can make with their hole cards.
+	Connectedness int
+	Straights     int
+
+	HighCard CardRank
+	Height   BoardHeight
+
+	FlushPossible    bool // Some hole cards make a flush
+	StraightPossible bool // Some hole cards make a straight
+
+	// PossibleDraws lists the kinds of draw some hole cards hold, as
+	// AnalyzeDraws finds them. It is empty on the river.
+	PossibleDraws []DrawKind
+
+	// Wetness is the share of hole card combinations that make a straight
+	// or better or hold a flush draw, an open-ended straight draw or a
+	// double gutshot: 0 for the driest boards, rising as more hands connect.
+	Wetness float64
+
+	// Changes describes each street dealt after the flop.
+	Changes []TextureChange
+}
+
+// TextureChange is how a turn or river card changes a board's texture.
+type TextureChange struct {
+	Street BoardState
+	Card   Card
+
+	PairsBoard        bool // The card's rank was already on the board
+	CompletesFlush    bool // The card makes a flush possible for the first time
+	CompletesStraight bool // The card makes more straights possible
+	Overcard          bool // The card is higher than every earlier board card
+
+	Wetness float64 // The board's wetness with the card
+}
+
+// Texture analyzes a flop, turn or river. It returns ErrInvalidBoardSize
+// before the flop, ErrInvalidCard for a card out of range and
+// ErrDuplicateCards if a card repeats.
+func (b *Board) Texture() (*BoardTexture, error) {
+	if err := validateBoard(b); err != nil {
+		return nil, err
+	}
+
+	// Each street's texture is computed once and compared with the next
+	streets := make([]*BoardTexture, len(b.Cards)+1)
+	for n := 3; n <= len(b.Cards); n++ {
+		streets[n] = boardTexture(b.Cards[:n])
+	}
+	texture := streets[len(b.Cards)]
+	for n := 4; n <= len(b.Cards); n++ {
+		before, after := streets[n-1], streets[n]
+		card := b.Cards[n-1]
+		texture.Changes = append(texture.Changes, TextureChange{
+			Street:            (&Board{Cards: b.Cards[:n]}).State(),
+			Card:              card,
+			PairsBoard:        rankMask(b.Cards[:n-1])&(1<<card.Rank) != 0,
+			CompletesFlush:    !before.FlushPossible && after.FlushPossible,
+			CompletesStraight: after.Straights > before.Straights,
+			Overcard:          card.Rank > before.HighCard,
+			Wetness:           after.Wetness,
+		})
+	}
+	return texture, nil
+}
+
+// boardTexture analyzes a valid board of 3 to 5 cards, without Changes.
+func boardTexture(board []Card) *BoardTexture {
+	t := &BoardTexture{}
+
+	var counts [Ace + 1]int
+	var suits [4]int
+	for _, c := range board {
+		counts[c.Rank]++
+		suits[c.Suit]++
+	}
+	pairs, trips, quads := 0, 0, 0
+	for _, n := range counts {
+		switch n {
+		case 2:
+			pairs++
+		case 3:
+			trips++
+		case 4:
+			quads++
+		}
+	}
+	switch {
+	case quads > 0:
+		t.Pairing = BoardQuads
+	case trips > 0 && pairs > 0:
+		t.Pairing = BoardFullHouse
+	case trips > 0:
+		t.Pairing = BoardTrips
+	case pairs > 1:
+		t.Pairing = BoardTwoPaired
+	case pairs == 1:
+		t.Pairing = BoardPaired
+	default:
+		t.Pairing = BoardUnpaired
+	}
+
+	t.SuitedCards = max(suits[0], suits[1], suits[2], suits[3])
+	switch {
+	case t.SuitedCards == len(board):
+		t.Suits = BoardMonotone
+	case t.SuitedCards == 1:
+		t.Suits = BoardRainbow
+	case t.SuitedCards == 2:
+		t.Suits = BoardTwoTone
+	case t.SuitedCards == 3:
+		t.Suits = BoardThreeFlush
+	default:
+		t.Suits = BoardFourFlush
+	}
+	t.FlushPossible = t.SuitedCards >= 3
+
+	ranks := rankMask(board)
+	for high := Five; high <= Ace; high++ {
+		window := straightValue << (high - 4)
+		if high == Five {
+			window = wheelStraightValue
+		}
+		n := popCount(cardSet(ranks & window))
+		t.Connectedness = max(t.Connectedness, n)
+		if n >= 3 {
+			t.Straights++
+		}
+	}
+	t.StraightPossible = t.Straights > 0
+
+	t.HighCard = topRanks(ranks, 1)[0]
+	switch {
+	case t.HighCard == Ace:
+		t.Height = AceHighBoard
+	case t.HighCard >= Queen:
+		t.Height = HighBoard
+	case t.HighCard >= Nine:
+		t.Height = MiddleBoard
+	default:
+		t.Height = LowBoard
+	}
+
+	t.PossibleDraws, t.Wetness = boardConnections(board)
+	return t
+}
+
+// boardConnections runs through every hole card combination left to find the
+// draws possible and the share of combinations that connect strongly.
+func boardConnections(board []Card) ([]DrawKind, float64) {
+	used, _ := newCardSet(board)
+	deck := (fullCardSet &^ used).cards()
+	drawing := len(board) < handSize
+	b := &Board{Cards: board}
+
+	var possible [Overcards + 1]bool
+	cards := append(append([]Card(nil), board...), Card{}, Card{})
+	connected, combos := 0, 0
+	var buf [2]Card
+	for hole := range CombinationsBuffer(deck, 2, buf[:]) {
+		combos++
+		copy(cards[len(board):], hole)
+		strong := evaluateCards(cards).Rank() >= Straight
+		if drawing {
+			info, _ := AnalyzeDraws(hole, b)
+			for _, d := range info.Draws {
+				possible[d.Kind] = true
+				switch d.Kind {
+				case FlushDraw, OpenEndedStraightDraw, DoubleGutshot:
+					strong = true
+				}
+			}
+		}
+		if strong {
+			connected++
+		}
+	}
+
+	var kinds []DrawKind
+	for kind, ok := range possible {
+		if ok {
+			kinds = append(kinds, DrawKind(kind))
+		}
+	}
+	return kinds, float64(connected) / float64(combos)
+}
@@ -0,0 +1,109 @@
+package goker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBoardTexture(t *testing.T) {
+	c := NewCard
+	tests := []struct {
+		name          string
+		cards         []Card
+		pairing       BoardPairing
+		suits         BoardSuits
+		connectedness int
+		straights     int
+		height        BoardHeight
+		flush         bool
+	}{
+		{"dry", []Card{c(King, Hearts), c(Seven, Diamonds), c(Two, Clubs)}, BoardUnpaired, BoardRainbow, 1, 0, HighBoard, false},
+		{"connected", []Card{c(Jack, Hearts), c(Ten, Hearts), c(Nine, Clubs)}, BoardUnpaired, BoardTwoTone, 3, 3, MiddleBoard, false},
+		{"wheel", []Card{c(Ace, Hearts), c(Two, Spades), c(Four, Clubs)}, BoardUnpaired, BoardRainbow, 3, 1, AceHighBoard, false},
+		{"paired", []Card{c(Seven, Hearts), c(Seven, Spades), c(Two, Clubs)}, BoardPaired, BoardRainbow, 1, 0, LowBoard, false},
+		{"trips", []Card{c(Seven, Hearts), c(Seven, Spades), c(Seven, Clubs)}, BoardTrips, BoardRainbow, 1, 0, LowBoard, false},
+		{"monotone", []Card{c(Ace, Hearts), c(Eight, Hearts), c(Three, Hearts)}, BoardUnpaired, BoardMonotone, 2, 0, AceHighBoard, true},
+		{"two paired turn", []Card{c(Queen, Hearts), c(Queen, Spades), c(Five, Clubs), c(Five, Hearts)}, BoardTwoPaired, BoardTwoTone, 1, 0, HighBoard, false},
+		{"four-flush river", []Card{c(Queen, Hearts), c(Nine, Hearts), c(Five, Hearts), c(Two, Hearts), c(Two, Spades)}, BoardPaired, BoardFourFlush, 2, 0, HighBoard, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := (&Board{Cards: tt.cards}).Texture()
+			if err != nil {
+				t.Fatalf("Texture error: %v", err)
+			}
+			if tx.Pairing != tt.pairing || tx.Suits != tt.suits || tx.Height != tt.height {
+				t.Errorf("Texture = %v, %v, %v, want %v, %v, %v", tx.Pairing, tx.Suits, tx.Height, tt.pairing, tt.suits, tt.height)
+			}
+			if tx.Connectedness != tt.connectedness || tx.Straights != tt.straights || tx.StraightPossible != (tt.straights > 0) {
+				t.Errorf("Texture connectedness = %d with %d straights, want %d with %d", tx.Connectedness, tx.Straights, tt.connectedness, tt.straights)
+			}
+			if tx.FlushPossible != tt.flush {
+				t.Errorf("FlushPossible = %v, want %v", tx.FlushPossible, tt.flush)
+			}
+			if len(tt.cards) == 5 && len(tx.PossibleDraws) != 0 {
+				t.Errorf("river has possible draws %v", tx.PossibleDraws)
+			}
+		})
+	}
+}
+
+func TestBoardTextureDraws(t *testing.T) {
+	dry, _ := (&Board{Cards: []Card{NewCard(King, Hearts), NewCard(Seven, Diamonds), NewCard(Two, Clubs)}}).Texture()
+	wet, _ := (&Board{Cards: []Card{NewCard(Jack, Hearts), NewCard(Ten, Hearts), NewCard(Nine, Clubs)}}).Texture()
+
+	if !slices.Equal(dry.PossibleDraws, []DrawKind{BackdoorFlushDraw, BackdoorStraightDraw}) {
+		t.Errorf("K72 rainbow possible draws = %v, want backdoors only", dry.PossibleDraws)
+	}
+	if dry.Wetness != 0 {
+		t.Errorf("K72 rainbow wetness = %.3f, want 0", dry.Wetness)
+	}
+	for _, kind := range []DrawKind{FlushDraw, OpenEndedStraightDraw, Gutshot} {
+		if !slices.Contains(wet.PossibleDraws, kind) {
+			t.Errorf("JT9 two-tone possible draws %v lack %v", wet.PossibleDraws, kind)
+		}
+	}
+	if wet.Wetness <= 0.2 || wet.Wetness > 1 {
+		t.Errorf("JT9 two-tone wetness = %.3f, want well above K72's", wet.Wetness)
+	}
+}
+
+func TestBoardTextureChanges(t *testing.T) {
+	board := &Board{Cards: []Card{NewCard(Jack, Hearts), NewCard(Ten, Hearts), NewCard(Nine, Clubs)}}
+	flop, _ := board.Texture()
+	if len(flop.Changes) != 0 {
+		t.Errorf("flop changes = %v, want none", flop.Changes)
+	}
+
+	board.SetTurn(NewCard(Ace, Hearts))
+	board.SetRiver(NewCard(Nine, Spades))
+	tx, err := board.Texture()
+	if err != nil {
+		t.Fatalf("Texture error: %v", err)
+	}
+	if len(tx.Changes) != 2 {
+		t.Fatalf("Texture has %d changes, want 2", len(tx.Changes))
+	}
+
+	turn, river := tx.Changes[0], tx.Changes[1]
+	if turn.Street != Turn || !turn.CompletesFlush || !turn.CompletesStraight || !turn.Overcard || turn.PairsBoard {
+		t.Errorf("turn change = %+v, want a flush, a straight and an overcard", turn)
+	}
+	if river.Street != River || !river.PairsBoard || river.CompletesFlush || river.CompletesStraight || river.Overcard {
+		t.Errorf("river change = %+v, want the board pairing only", river)
+	}
+	if river.Wetness != tx.Wetness {
+		t.Errorf("river wetness = %.3f, want the board's %.3f", river.Wetness, tx.Wetness)
+	}
+}
+
+func TestBoardTextureInvalid(t *testing.T) {
+	if _, err := NewBoard().Texture(); err != ErrInvalidBoardSize {
+		t.Errorf("Texture preflop error = %v, want ErrInvalidBoardSize", err)
+	}
+	board := &Board{Cards: []Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(Two, Clubs)}}
+	if _, err := board.Texture(); err != ErrDuplicateCards {
+		t.Errorf("Texture with duplicates error = %v, want ErrDuplicateCards", err)
+	}
+}
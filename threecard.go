@@ -0,0 +1,283 @@
+package goker
+
+import (
+	"maps"
+	"sort"
+)
+
+const threeCardHandSize = 3
+
+// ThreeCardRank represents the ranking of a 3-card poker hand.
+// Straights are harder to make than flushes with three cards, so the
+// ordering differs from HandRank.
+type ThreeCardRank int
+
+const (
+	ThreeCardHighCard ThreeCardRank = iota + 1
+	ThreeCardPair
+	ThreeCardFlush
+	ThreeCardStraight
+	ThreeCardTrips
+	ThreeCardStraightFlush
+)
+
+func (r ThreeCardRank) String() string {
+	switch r {
+	case ThreeCardHighCard:
+		return "High Card"
+	case ThreeCardPair:
+		return "Pair"
+	case ThreeCardFlush:
+		return "Flush"
+	case ThreeCardStraight:
+		return "Straight"
+	case ThreeCardTrips:
+		return "Three of a Kind"
+	case ThreeCardStraightFlush:
+		return "Straight Flush"
+	default:
+		return "Unknown"
+	}
+}
+
+// ThreeCardHand represents a 3-card poker hand with evaluation capabilities.
+type ThreeCardHand struct {
+	Cards []Card
+
+	handRank   ThreeCardRank
+	isStraight bool
+	isWheel    bool
+}
+
+// NewThreeCardHand creates a new ThreeCardHand from 3 cards.
+func NewThreeCardHand(cards []Card) (*ThreeCardHand, error) {
+	if len(cards) != threeCardHandSize {
+		return nil, ErrInvalidThreeCardHandSize
+	}
+
+	if hasDuplicateCards(cards) {
+		return nil, ErrDuplicateCards
+	}
+
+	sortedCards := make([]Card, len(cards))
+	copy(sortedCards, cards)
+	sort.Slice(sortedCards, func(i, j int) bool {
+		return sortedCards[i].Rank > sortedCards[j].Rank
+	})
+
+	h := &ThreeCardHand{Cards: sortedCards}
+	h.evaluate()
+	return h, nil
+}
+
+// evaluate computes and caches the hand rank.
+func (h *ThreeCardHand) evaluate() {
+	high, mid, low := h.Cards[0].Rank, h.Cards[1].Rank, h.Cards[2].Rank
+
+	isFlush := h.Cards[0].Suit == h.Cards[1].Suit && h.Cards[1].Suit == h.Cards[2].Suit
+	distinct := high != mid && mid != low
+	h.isWheel = high == Ace && mid == Three && low == Two
+	h.isStraight = distinct && (high-low == 2 || h.isWheel)
+
+	switch {
+	case h.isStraight && isFlush:
+		h.handRank = ThreeCardStraightFlush
+	case high == low:
+		h.handRank = ThreeCardTrips
+	case h.isStraight:
+		h.handRank = ThreeCardStraight
+	case isFlush:
+		h.handRank = ThreeCardFlush
+	case !distinct:
+		h.handRank = ThreeCardPair
+	default:
+		h.handRank = ThreeCardHighCard
+	}
+}
+
+// Rank returns the evaluated hand rank.
+func (h *ThreeCardHand) Rank() ThreeCardRank {
+	return h.handRank
+}
+
+// IsStraight returns true if the hand is a straight (including straight flushes).
+func (h *ThreeCardHand) IsStraight() bool {
+	return h.isStraight
+}
+
+// HighCard returns the highest card in the hand. For the A-2-3 straight
+// the three is the high card.
+func (h *ThreeCardHand) HighCard() Card {
+	if h.isWheel {
+		return h.Cards[1]
+	}
+	return h.Cards[0]
+}
+
+// TiebreakScore returns a numeric score for breaking ties between hands of equal rank.
+func (h *ThreeCardHand) TiebreakScore() int {
+	if h.isStraight {
+		return int(h.HighCard().Rank)
+	}
+	return tiebreakScore(h.Cards)
+}
+
+// Compare compares two hands. Returns -1, 0 or 1 like Hand.Compare.
+func (h *ThreeCardHand) Compare(other *ThreeCardHand) int {
+	if h.handRank != other.handRank {
+		if h.handRank > other.handRank {
+			return 1
+		}
+		return -1
+	}
+
+	myScore := h.TiebreakScore()
+	otherScore := other.TiebreakScore()
+	if myScore > otherScore {
+		return 1
+	}
+	if myScore < otherScore {
+		return -1
+	}
+	return 0
+}
+
+// Beats returns true if this hand beats the other.
+func (h *ThreeCardHand) Beats(other *ThreeCardHand) bool {
+	return h.Compare(other) > 0
+}
+
+// String returns a string representation of the hand.
+func (h *ThreeCardHand) String() string {
+	s := "<ThreeCardHand: "
+	for i, card := range h.Cards {
+		if i > 0 {
+			s += " "
+		}
+		s += card.String()
+	}
+	s += ">"
+	return s
+}
+
+// Default Three Card Poker paytables, as "to one" multipliers of the wager.
+var (
+	// DefaultPairPlusPayTable pays the pair plus side bet on the player's
+	// hand alone, by the standard 40/30/6/4/1 table.
+	DefaultPairPlusPayTable = map[ThreeCardRank]int{
+		ThreeCardStraightFlush: 40,
+		ThreeCardTrips:         30,
+		ThreeCardStraight:      6,
+		ThreeCardFlush:         4,
+		ThreeCardPair:          1,
+	}
+
+	// DefaultAnteBonusPayTable pays a bonus on the ante for premium hands
+	// whenever the player plays, regardless of the dealer's hand.
+	DefaultAnteBonusPayTable = map[ThreeCardRank]int{
+		ThreeCardStraightFlush: 5,
+		ThreeCardTrips:         4,
+		ThreeCardStraight:      1,
+	}
+)
+
+// ThreeCardPoker represents a dealer-vs-player game of Three Card Poker.
+type ThreeCardPoker struct {
+	Deck   *Deck
+	Player *ThreeCardHand
+	Dealer *ThreeCardHand
+
+	PairPlusPayTable  map[ThreeCardRank]int
+	AnteBonusPayTable map[ThreeCardRank]int
+}
+
+// NewThreeCardPoker creates a new game with copies of the default paytables,
+// which it can change without affecting other games
+// (automatically shuffles and deals the player and dealer hands).
+func NewThreeCardPoker() *ThreeCardPoker {
+	g := &ThreeCardPoker{
+		Deck:              NewDeck(),
+		PairPlusPayTable:  maps.Clone(DefaultPairPlusPayTable),
+		AnteBonusPayTable: maps.Clone(DefaultAnteBonusPayTable),
+	}
+	g.Deal()
+	return g
+}
+
+// Deal deals 3 cards each to the player and the dealer.
+func (g *ThreeCardPoker) Deal() error {
+	playerCards, err := g.Deck.DrawMany(threeCardHandSize)
+	if err != nil {
+		return err
+	}
+	dealerCards, err := g.Deck.DrawMany(threeCardHandSize)
+	if err != nil {
+		return err
+	}
+
+	if g.Player, err = NewThreeCardHand(playerCards); err != nil {
+		return err
+	}
+	g.Dealer, err = NewThreeCardHand(dealerCards)
+	return err
+}
+
+// DealerQualifies returns true if the dealer holds Queen-high or better.
+func (g *ThreeCardPoker) DealerQualifies() bool {
+	return g.Dealer.Rank() > ThreeCardHighCard || g.Dealer.HighCard().Rank >= Queen
+}
+
+// ThreeCardPokerResult holds the net amount won (positive) or lost (negative)
+// on each wager of a Three Card Poker hand.
+type ThreeCardPokerResult struct {
+	Ante            int
+	Play            int
+	AnteBonus       int
+	PairPlus        int
+	DealerQualifies bool
+}
+
+// Net returns the total amount won or lost across all wagers.
+func (r ThreeCardPokerResult) Net() int {
+	return r.Ante + r.Play + r.AnteBonus + r.PairPlus
+}
+
+// Settle resolves the hand for the given ante and pair plus wagers (pair plus
+// may be 0). If play is false the player folds and forfeits the ante;
+// otherwise a play wager equal to the ante is made. A non-qualifying dealer
+// pays the ante and pushes the play wager. The pair plus wager is settled
+// independently of the dealer and of folding.
+func (g *ThreeCardPoker) Settle(ante, pairPlus int, play bool) ThreeCardPokerResult {
+	result := ThreeCardPokerResult{DealerQualifies: g.DealerQualifies()}
+
+	if pairPlus > 0 {
+		if multiplier, ok := g.PairPlusPayTable[g.Player.Rank()]; ok {
+			result.PairPlus = pairPlus * multiplier
+		} else {
+			result.PairPlus = -pairPlus
+		}
+	}
+
+	if !play {
+		result.Ante = -ante
+		return result
+	}
+
+	result.AnteBonus = ante * g.AnteBonusPayTable[g.Player.Rank()]
+
+	if !result.DealerQualifies {
+		result.Ante = ante
+		return result
+	}
+
+	switch g.Player.Compare(g.Dealer) {
+	case 1:
+		result.Ante = ante
+		result.Play = ante
+	case -1:
+		result.Ante = -ante
+		result.Play = -ante
+	}
+
+	return result
+}
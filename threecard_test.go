@@ -0,0 +1,248 @@
+package goker
+
+import "testing"
+
+func makeThreeCardHand(t *testing.T, cards ...Card) *ThreeCardHand {
+	t.Helper()
+	h, err := NewThreeCardHand(cards)
+	if err != nil {
+		t.Fatalf("Failed to create three card hand: %v", err)
+	}
+	return h
+}
+
+func TestNewThreeCardHandInvalid(t *testing.T) {
+	_, err := NewThreeCardHand([]Card{NewCard(Ace, Spades)})
+	if err != ErrInvalidThreeCardHandSize {
+		t.Errorf("NewThreeCardHand() error = %v, want ErrInvalidThreeCardHandSize", err)
+	}
+
+	_, err = NewThreeCardHand([]Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(King, Spades)})
+	if err != ErrDuplicateCards {
+		t.Errorf("NewThreeCardHand() error = %v, want ErrDuplicateCards", err)
+	}
+}
+
+func TestThreeCardHandRank(t *testing.T) {
+	tests := []struct {
+		cards []Card
+		rank  ThreeCardRank
+	}{
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Hearts), NewCard(Nine, Clubs)}, ThreeCardHighCard},
+		{[]Card{NewCard(Nine, Spades), NewCard(Nine, Hearts), NewCard(Two, Clubs)}, ThreeCardPair},
+		{[]Card{NewCard(Ace, Spades), NewCard(Nine, Spades), NewCard(Two, Spades)}, ThreeCardFlush},
+		{[]Card{NewCard(Four, Spades), NewCard(Five, Hearts), NewCard(Six, Clubs)}, ThreeCardStraight},
+		{[]Card{NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs)}, ThreeCardStraight},
+		{[]Card{NewCard(Ace, Spades), NewCard(King, Hearts), NewCard(Queen, Clubs)}, ThreeCardStraight},
+		{[]Card{NewCard(Four, Spades), NewCard(Four, Hearts), NewCard(Four, Clubs)}, ThreeCardTrips},
+		{[]Card{NewCard(Jack, Hearts), NewCard(Queen, Hearts), NewCard(King, Hearts)}, ThreeCardStraightFlush},
+		// K-A-2 does not wrap around
+		{[]Card{NewCard(King, Spades), NewCard(Ace, Hearts), NewCard(Two, Clubs)}, ThreeCardHighCard},
+	}
+
+	for _, tt := range tests {
+		h := makeThreeCardHand(t, tt.cards...)
+		if h.Rank() != tt.rank {
+			t.Errorf("%v.Rank() = %v, want %v", h, h.Rank(), tt.rank)
+		}
+	}
+}
+
+func TestThreeCardRankOrdering(t *testing.T) {
+	// A straight beats a flush with three cards
+	straight := makeThreeCardHand(t, NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Clubs))
+	flush := makeThreeCardHand(t, NewCard(Ace, Hearts), NewCard(King, Hearts), NewCard(Nine, Hearts))
+	if !straight.Beats(flush) {
+		t.Error("Straight should beat flush")
+	}
+
+	trips := makeThreeCardHand(t, NewCard(Two, Spades), NewCard(Two, Hearts), NewCard(Two, Clubs))
+	if !trips.Beats(straight) {
+		t.Error("Trips should beat straight")
+	}
+}
+
+func TestThreeCardHandCompare(t *testing.T) {
+	wheel := makeThreeCardHand(t, NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs))
+	twoToFour := makeThreeCardHand(t, NewCard(Two, Spades), NewCard(Three, Hearts), NewCard(Four, Clubs))
+	if wheel.Compare(twoToFour) != -1 {
+		t.Error("A-2-3 should be the lowest straight")
+	}
+
+	pairAceKicker := makeThreeCardHand(t, NewCard(Nine, Spades), NewCard(Nine, Hearts), NewCard(Ace, Clubs))
+	pairKingKicker := makeThreeCardHand(t, NewCard(Nine, Diamonds), NewCard(Nine, Clubs), NewCard(King, Clubs))
+	if pairAceKicker.Compare(pairKingKicker) != 1 {
+		t.Error("Kicker should break pair ties")
+	}
+
+	same := makeThreeCardHand(t, NewCard(Nine, Diamonds), NewCard(Nine, Clubs), NewCard(Ace, Hearts))
+	if pairAceKicker.Compare(same) != 0 {
+		t.Error("Identical ranks should tie")
+	}
+}
+
+func TestThreeCardRankString(t *testing.T) {
+	tests := []struct {
+		rank     ThreeCardRank
+		expected string
+	}{
+		{ThreeCardHighCard, "High Card"},
+		{ThreeCardPair, "Pair"},
+		{ThreeCardFlush, "Flush"},
+		{ThreeCardStraight, "Straight"},
+		{ThreeCardTrips, "Three of a Kind"},
+		{ThreeCardStraightFlush, "Straight Flush"},
+		{ThreeCardRank(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rank.String(); got != tt.expected {
+			t.Errorf("ThreeCardRank(%d).String() = %s, want %s", tt.rank, got, tt.expected)
+		}
+	}
+}
+
+func TestNewThreeCardPoker(t *testing.T) {
+	g := NewThreeCardPoker()
+
+	if g.Player == nil || g.Dealer == nil {
+		t.Fatal("NewThreeCardPoker() should deal player and dealer hands")
+	}
+	if g.Deck.Len() != 52-6 {
+		t.Errorf("Deck has %d cards, want 46", g.Deck.Len())
+	}
+
+	// Each game's paytables are its own
+	g.PairPlusPayTable[ThreeCardPair] = 2
+	delete(g.AnteBonusPayTable, ThreeCardStraight)
+	if DefaultPairPlusPayTable[ThreeCardPair] != 1 || DefaultAnteBonusPayTable[ThreeCardStraight] != 1 {
+		t.Error("Changing a game's paytables changed the defaults")
+	}
+	if other := NewThreeCardPoker(); other.PairPlusPayTable[ThreeCardPair] != 1 {
+		t.Error("Changing a game's paytables changed another game's")
+	}
+}
+
+func newThreeCardPokerWithHands(t *testing.T, player, dealer []Card) *ThreeCardPoker {
+	t.Helper()
+	return &ThreeCardPoker{
+		Player:            makeThreeCardHand(t, player...),
+		Dealer:            makeThreeCardHand(t, dealer...),
+		PairPlusPayTable:  DefaultPairPlusPayTable,
+		AnteBonusPayTable: DefaultAnteBonusPayTable,
+	}
+}
+
+func TestThreeCardPokerDealerQualifies(t *testing.T) {
+	g := newThreeCardPokerWithHands(t,
+		[]Card{NewCard(Two, Spades), NewCard(Five, Hearts), NewCard(Nine, Clubs)},
+		[]Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+	)
+	if !g.DealerQualifies() {
+		t.Error("Queen-high dealer should qualify")
+	}
+
+	g.Dealer = makeThreeCardHand(t, NewCard(Jack, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs))
+	if g.DealerQualifies() {
+		t.Error("Jack-high dealer should not qualify")
+	}
+
+	g.Dealer = makeThreeCardHand(t, NewCard(Three, Spades), NewCard(Three, Diamonds), NewCard(Two, Clubs))
+	if !g.DealerQualifies() {
+		t.Error("Paired dealer should qualify")
+	}
+}
+
+func TestThreeCardPokerSettle(t *testing.T) {
+	tests := []struct {
+		name     string
+		player   []Card
+		dealer   []Card
+		pairPlus int
+		play     bool
+		want     ThreeCardPokerResult
+	}{
+		{
+			name:   "fold",
+			player: []Card{NewCard(Two, Spades), NewCard(Five, Hearts), NewCard(Nine, Clubs)},
+			dealer: []Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+			play:   false,
+			want:   ThreeCardPokerResult{Ante: -10, DealerQualifies: true},
+		},
+		{
+			name:   "dealer does not qualify",
+			player: []Card{NewCard(Two, Spades), NewCard(Five, Hearts), NewCard(Nine, Clubs)},
+			dealer: []Card{NewCard(Jack, Spades), NewCard(Five, Diamonds), NewCard(Three, Clubs)},
+			play:   true,
+			want:   ThreeCardPokerResult{Ante: 10},
+		},
+		{
+			name:   "player wins",
+			player: []Card{NewCard(King, Spades), NewCard(King, Hearts), NewCard(Nine, Clubs)},
+			dealer: []Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+			play:   true,
+			want:   ThreeCardPokerResult{Ante: 10, Play: 10, DealerQualifies: true},
+		},
+		{
+			name:   "player loses",
+			player: []Card{NewCard(King, Spades), NewCard(Five, Hearts), NewCard(Nine, Clubs)},
+			dealer: []Card{NewCard(Queen, Spades), NewCard(Queen, Diamonds), NewCard(Two, Clubs)},
+			play:   true,
+			want:   ThreeCardPokerResult{Ante: -10, Play: -10, DealerQualifies: true},
+		},
+		{
+			name:   "push",
+			player: []Card{NewCard(Queen, Hearts), NewCard(Five, Hearts), NewCard(Two, Spades)},
+			dealer: []Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+			play:   true,
+			want:   ThreeCardPokerResult{DealerQualifies: true},
+		},
+		{
+			name:     "straight flush bonuses",
+			player:   []Card{NewCard(Jack, Hearts), NewCard(Queen, Hearts), NewCard(King, Hearts)},
+			dealer:   []Card{NewCard(Ace, Spades), NewCard(Ace, Diamonds), NewCard(Two, Clubs)},
+			pairPlus: 5,
+			play:     true,
+			want:     ThreeCardPokerResult{Ante: 10, Play: 10, AnteBonus: 50, PairPlus: 200, DealerQualifies: true},
+		},
+		{
+			name:     "flush pays pair plus 4 to 1",
+			player:   []Card{NewCard(Two, Hearts), NewCard(Seven, Hearts), NewCard(Jack, Hearts)},
+			dealer:   []Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+			pairPlus: 5,
+			play:     true,
+			want:     ThreeCardPokerResult{Ante: 10, Play: 10, PairPlus: 20, DealerQualifies: true},
+		},
+		{
+			name:     "pair plus lost on fold",
+			player:   []Card{NewCard(Two, Spades), NewCard(Five, Hearts), NewCard(Nine, Clubs)},
+			dealer:   []Card{NewCard(Queen, Spades), NewCard(Five, Diamonds), NewCard(Two, Clubs)},
+			pairPlus: 5,
+			play:     false,
+			want:     ThreeCardPokerResult{Ante: -10, PairPlus: -5, DealerQualifies: true},
+		},
+		{
+			name:     "pair plus paid on fold",
+			player:   []Card{NewCard(Two, Spades), NewCard(Two, Hearts), NewCard(Nine, Clubs)},
+			dealer:   []Card{NewCard(Ace, Spades), NewCard(Ace, Diamonds), NewCard(Three, Clubs)},
+			pairPlus: 5,
+			play:     false,
+			want:     ThreeCardPokerResult{Ante: -10, PairPlus: 5, DealerQualifies: true},
+		},
+	}
+
+	for _, tt := range tests {
+		g := newThreeCardPokerWithHands(t, tt.player, tt.dealer)
+		got := g.Settle(10, tt.pairPlus, tt.play)
+		if got != tt.want {
+			t.Errorf("%s: Settle() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThreeCardPokerResultNet(t *testing.T) {
+	r := ThreeCardPokerResult{Ante: 10, Play: 10, AnteBonus: 50, PairPlus: -5}
+	if r.Net() != 65 {
+		t.Errorf("Net() = %d, want 65", r.Net())
+	}
+}
@@ -0,0 +1,317 @@
+package goker
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// BlindLevel is one level of a tournament's blind schedule. A level ends after
+// Duration has elapsed or Hands hands have been played, whichever comes first;
+// zero disables that limit.
+type BlindLevel struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Duration   time.Duration
+	Hands      int
+
+	// Break marks a break between levels; no hands are played and the
+	// blinds of the previous level stay in effect.
+	Break bool
+
+	// ColorUp is the chip denomination removed when this level starts
+	// (0 if none), coloring stacks up to ColorUpTo chips. ApplyLevel races
+	// off each table's odd chips; see ColorUp.
+	ColorUp   int
+	ColorUpTo int
+}
+
+// Tournament owns a blind schedule and advances through its levels on a clock
+// or by hand count. The clock is injectable so schedules can be tested
+// without real waiting.
+type Tournament struct {
+	Levels   []BlindLevel
+	AnteType AnteType
+	Rand     *rand.Rand // Deals chip races; nil uses the default source
+
+	now          func() time.Time
+	level        int
+	levelStart   time.Time
+	handsInLevel int
+	started      bool
+}
+
+// NewTournament creates a tournament with the given blind schedule. now
+// provides the current time; if nil, time.Now is used. Every level except the
+// last must end on a duration or hand count, and levels that color up need
+// positive denominations.
+func NewTournament(levels []BlindLevel, now func() time.Time) (*Tournament, error) {
+	if len(levels) == 0 {
+		return nil, ErrInvalidBlindLevel
+	}
+	for _, lvl := range levels[:len(levels)-1] {
+		if lvl.Duration <= 0 && lvl.Hands <= 0 {
+			return nil, ErrInvalidBlindLevel
+		}
+		if !lvl.Break && lvl.BigBlind <= 0 {
+			return nil, ErrInvalidBlindLevel
+		}
+	}
+	if last := levels[len(levels)-1]; last.Break || last.BigBlind <= 0 {
+		return nil, ErrInvalidBlindLevel
+	}
+	for _, lvl := range levels {
+		if lvl.ColorUp < 0 || lvl.ColorUp > 0 && lvl.ColorUpTo <= 0 {
+			return nil, ErrInvalidBlindLevel
+		}
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Tournament{
+		Levels:   levels,
+		AnteType: StandardAnte,
+		now:      now,
+	}, nil
+}
+
+// Start starts the clock on the first level.
+func (t *Tournament) Start() {
+	t.level = 0
+	t.levelStart = t.now()
+	t.handsInLevel = 0
+	t.started = true
+}
+
+// Level returns the index of the current level.
+func (t *Tournament) Level() int {
+	return t.level
+}
+
+// CurrentLevel returns the current level, which may be a break.
+func (t *Tournament) CurrentLevel() BlindLevel {
+	return t.Levels[t.level]
+}
+
+// OnBreak returns true if the tournament is on a break.
+func (t *Tournament) OnBreak() bool {
+	return t.Levels[t.level].Break
+}
+
+// BlindsLevel returns the level whose blinds are in effect: the current level,
+// or during a break the level before it (the level after it if the schedule
+// starts with a break).
+func (t *Tournament) BlindsLevel() BlindLevel {
+	for i := t.level; i >= 0; i-- {
+		if !t.Levels[i].Break {
+			return t.Levels[i]
+		}
+	}
+	for i := t.level + 1; i < len(t.Levels); i++ {
+		if !t.Levels[i].Break {
+			return t.Levels[i]
+		}
+	}
+	return t.Levels[t.level]
+}
+
+// TimeRemaining returns the time left in the current level, or 0 if the level
+// has no duration or the tournament hasn't started.
+func (t *Tournament) TimeRemaining() time.Duration {
+	lvl := t.Levels[t.level]
+	if !t.started || lvl.Duration <= 0 {
+		return 0
+	}
+	remaining := lvl.Duration - t.now().Sub(t.levelStart)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Update advances past every level whose duration has elapsed and returns
+// true if the level changed. Call it periodically, e.g. before each hand.
+func (t *Tournament) Update() bool {
+	if !t.started {
+		return false
+	}
+
+	changed := false
+	for t.level < len(t.Levels)-1 {
+		lvl := t.Levels[t.level]
+		if lvl.Duration <= 0 || t.now().Sub(t.levelStart) < lvl.Duration {
+			break
+		}
+		t.advance(t.levelStart.Add(lvl.Duration))
+		changed = true
+	}
+	return changed
+}
+
+// RecordHand records a completed hand and returns true if it ended the level.
+func (t *Tournament) RecordHand() bool {
+	if !t.started {
+		return false
+	}
+
+	t.handsInLevel++
+	lvl := t.Levels[t.level]
+	if lvl.Hands > 0 && t.handsInLevel >= lvl.Hands && t.level < len(t.Levels)-1 {
+		t.advance(t.now())
+		return true
+	}
+	return t.Update()
+}
+
+// advance moves to the next level, starting its clock at start.
+func (t *Tournament) advance(start time.Time) {
+	t.level++
+	t.levelStart = start
+	t.handsInLevel = 0
+}
+
+// NewTable creates a table using the blinds and ante currently in effect.
+func (t *Tournament) NewTable(numSeats int) *Table {
+	table := NewTable(numSeats, 0, 0, TableOptions{})
+	t.applyBlinds(table)
+	return table
+}
+
+// ApplyLevel updates a table to the blinds and ante currently in effect and
+// colors up its stacks for every level started so far that removes a chip
+// denomination. Call it between hands. Stacks already colored up are left
+// alone, so applying a level again changes nothing. If a level started so
+// far has an invalid color-up, as Levels may after NewTournament checked it,
+// ApplyLevel returns ErrInvalidBlindLevel and leaves the table alone.
+func (t *Tournament) ApplyLevel(table *Table) error {
+	started := t.Levels[:t.level+1]
+	for _, lvl := range started {
+		if lvl.ColorUp < 0 || lvl.ColorUp > 0 && lvl.ColorUpTo <= 0 {
+			return ErrInvalidBlindLevel
+		}
+	}
+
+	t.applyBlinds(table)
+	for _, lvl := range started {
+		if lvl.ColorUp > 0 {
+			if err := t.colorUpTable(table, lvl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyBlinds sets a table's blinds and ante to those currently in effect.
+func (t *Tournament) applyBlinds(table *Table) {
+	lvl := t.BlindsLevel()
+	table.SmallBlind = lvl.SmallBlind
+	table.BigBlind = lvl.BigBlind
+	table.Options.Ante = lvl.Ante
+	table.Options.AnteType = NoAnte
+	if lvl.Ante > 0 {
+		table.Options.AnteType = t.AnteType
+	}
+}
+
+// colorUpTable runs a level's color-up on the stacks seated at a table.
+func (t *Tournament) colorUpTable(table *Table, lvl BlindLevel) error {
+	var stacks []int
+	var seats []*Seat
+	for _, s := range table.Seats {
+		if s != nil {
+			stacks = append(stacks, s.Stack)
+			seats = append(seats, s)
+		}
+	}
+	colored, err := ColorUp(stacks, lvl.ColorUp, lvl.ColorUpTo, t.Rand)
+	if err != nil {
+		return err
+	}
+	for i, s := range seats {
+		s.Stack = colored[i]
+	}
+	return nil
+}
+
+// ColorUp removes a chip denomination by coloring every stack up to the
+// colorUpTo denomination. Whole colorUpTo chips' worth of small chips are
+// exchanged directly; the odd chips left over are settled by a chip race:
+// each player is dealt one card per odd chip, and the players holding the
+// highest cards each win one colorUpTo chip until the total odd chip value
+// (rounded to the nearest colorUpTo) has been awarded. No player wins more
+// than one chip in the race, and no player can be raced out of the
+// tournament. If rng is nil the deck is shuffled with the default source.
+// It returns ErrInvalidDenomination unless both denominations are positive.
+func ColorUp(stacks []int, denomination, colorUpTo int, rng *rand.Rand) ([]int, error) {
+	if denomination <= 0 || colorUpTo <= 0 {
+		return nil, ErrInvalidDenomination
+	}
+
+	type raceCard struct {
+		player int
+		card   Card
+	}
+
+	newDeck := func() *Deck {
+		if rng != nil {
+			return NewSeededDeck(rng)
+		}
+		return NewDeck()
+	}
+
+	deck := newDeck()
+	result := make([]int, len(stacks))
+	var race []raceCard
+	totalOdd := 0
+
+	for i, stack := range stacks {
+		odd := stack % colorUpTo
+		result[i] = stack - odd
+		if odd == 0 {
+			continue
+		}
+		totalOdd += odd
+
+		numCards := (odd + denomination - 1) / denomination
+		var best Card
+		for c := 0; c < numCards; c++ {
+			if deck.Len() == 0 {
+				deck = newDeck()
+			}
+			card, _ := deck.Draw()
+			if c == 0 || chipRaceBeats(card, best) {
+				best = card
+			}
+		}
+		race = append(race, raceCard{i, best})
+	}
+
+	sort.Slice(race, func(i, j int) bool {
+		return chipRaceBeats(race[i].card, race[j].card)
+	})
+
+	awards := (totalOdd + colorUpTo/2) / colorUpTo
+	for i := 0; i < awards && i < len(race); i++ {
+		result[race[i].player] += colorUpTo
+	}
+
+	// A player can't be raced out of the tournament
+	for i, stack := range stacks {
+		if stack > 0 && result[i] == 0 {
+			result[i] = colorUpTo
+		}
+	}
+
+	return result, nil
+}
+
+// chipRaceBeats orders chip race cards by rank, then by suit (spades highest).
+func chipRaceBeats(a, b Card) bool {
+	if a.Rank != b.Rank {
+		return a.Rank > b.Rank
+	}
+	return a.Suit > b.Suit
+}
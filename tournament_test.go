@@ -0,0 +1,285 @@
+package goker
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for testing level timing.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func testBlindSchedule() []BlindLevel {
+	return []BlindLevel{
+		{SmallBlind: 25, BigBlind: 50, Duration: 20 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Ante: 10, Duration: 20 * time.Minute},
+		{Break: true, Duration: 10 * time.Minute},
+		{SmallBlind: 100, BigBlind: 200, Ante: 25, Hands: 3, ColorUp: 25, ColorUpTo: 100},
+		{SmallBlind: 200, BigBlind: 400, Ante: 50},
+	}
+}
+
+func newTestTournament(t *testing.T) (*Tournament, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tourney, err := NewTournament(testBlindSchedule(), clock.Now)
+	if err != nil {
+		t.Fatalf("NewTournament() error = %v", err)
+	}
+	tourney.Start()
+	return tourney, clock
+}
+
+func TestNewTournamentInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []BlindLevel
+	}{
+		{"empty", nil},
+		{"level never ends", []BlindLevel{{SmallBlind: 25, BigBlind: 50}, {SmallBlind: 50, BigBlind: 100}}},
+		{"no big blind", []BlindLevel{{Duration: time.Minute}, {SmallBlind: 50, BigBlind: 100}}},
+		{"ends on break", []BlindLevel{{SmallBlind: 25, BigBlind: 50, Duration: time.Minute}, {Break: true}}},
+		{"color up to nothing", []BlindLevel{{SmallBlind: 25, BigBlind: 50, ColorUp: 25}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewTournament(tt.levels, nil); err != ErrInvalidBlindLevel {
+			t.Errorf("%s: NewTournament() error = %v, want ErrInvalidBlindLevel", tt.name, err)
+		}
+	}
+}
+
+func TestTournamentClock(t *testing.T) {
+	tourney, clock := newTestTournament(t)
+
+	if tourney.Level() != 0 || tourney.CurrentLevel().BigBlind != 50 {
+		t.Fatalf("Starting level = %d (%+v)", tourney.Level(), tourney.CurrentLevel())
+	}
+
+	clock.Advance(5 * time.Minute)
+	if tourney.Update() {
+		t.Error("Update() should not change level before the duration elapses")
+	}
+	if got := tourney.TimeRemaining(); got != 15*time.Minute {
+		t.Errorf("TimeRemaining() = %v, want 15m", got)
+	}
+
+	clock.Advance(15 * time.Minute)
+	if !tourney.Update() || tourney.Level() != 1 {
+		t.Errorf("Update() after 20m: level = %d, want 1", tourney.Level())
+	}
+
+	// A long gap advances through several levels, carrying over elapsed time
+	clock.Advance(25 * time.Minute)
+	tourney.Update()
+	if tourney.Level() != 2 || !tourney.OnBreak() {
+		t.Errorf("Level = %d, OnBreak = %v, want 2, true", tourney.Level(), tourney.OnBreak())
+	}
+	if got := tourney.TimeRemaining(); got != 5*time.Minute {
+		t.Errorf("TimeRemaining() on break = %v, want 5m", got)
+	}
+
+	// The previous level's blinds stay in effect during the break
+	if lvl := tourney.BlindsLevel(); lvl.BigBlind != 100 {
+		t.Errorf("BlindsLevel() on break = %+v, want the 50/100 level", lvl)
+	}
+
+	clock.Advance(5 * time.Minute)
+	tourney.Update()
+	if tourney.Level() != 3 {
+		t.Errorf("Level after break = %d, want 3", tourney.Level())
+	}
+	if tourney.CurrentLevel().ColorUp != 25 {
+		t.Errorf("ColorUp = %d, want 25", tourney.CurrentLevel().ColorUp)
+	}
+}
+
+func TestTournamentHandsPerLevel(t *testing.T) {
+	tourney, clock := newTestTournament(t)
+	clock.Advance(50 * time.Minute)
+	tourney.Update()
+
+	for i := 0; i < 2; i++ {
+		if tourney.RecordHand() {
+			t.Fatalf("RecordHand() %d should not end the level", i+1)
+		}
+	}
+	if got := tourney.TimeRemaining(); got != 0 {
+		t.Errorf("TimeRemaining() for a hands level = %v, want 0", got)
+	}
+	if !tourney.RecordHand() || tourney.Level() != 4 {
+		t.Errorf("Level after 3 hands = %d, want 4", tourney.Level())
+	}
+
+	// The last level never ends
+	clock.Advance(24 * time.Hour)
+	for i := 0; i < 100; i++ {
+		tourney.RecordHand()
+	}
+	if tourney.Level() != 4 {
+		t.Errorf("Level = %d, the last level should never end", tourney.Level())
+	}
+}
+
+func TestTournamentNotStarted(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tourney, _ := NewTournament(testBlindSchedule(), clock.Now)
+
+	clock.Advance(time.Hour)
+	if tourney.Update() || tourney.RecordHand() {
+		t.Error("Levels should not advance before Start()")
+	}
+	if tourney.TimeRemaining() != 0 {
+		t.Error("TimeRemaining() should be 0 before Start()")
+	}
+}
+
+func TestTournamentTables(t *testing.T) {
+	tourney, clock := newTestTournament(t)
+
+	table := tourney.NewTable(9)
+	if table.SmallBlind != 25 || table.BigBlind != 50 || table.Options.AnteType != NoAnte {
+		t.Errorf("NewTable() blinds = %d/%d ante %v", table.SmallBlind, table.BigBlind, table.Options.AnteType)
+	}
+
+	clock.Advance(20 * time.Minute)
+	tourney.Update()
+	if err := tourney.ApplyLevel(table); err != nil {
+		t.Fatalf("ApplyLevel() error = %v", err)
+	}
+	if table.BigBlind != 100 || table.Options.Ante != 10 || table.Options.AnteType != StandardAnte {
+		t.Errorf("ApplyLevel() = %d/%d ante %d (%v)", table.SmallBlind, table.BigBlind, table.Options.Ante, table.Options.AnteType)
+	}
+
+	tourney.AnteType = BigBlindAnte
+	if err := tourney.ApplyLevel(table); err != nil {
+		t.Fatalf("ApplyLevel() error = %v", err)
+	}
+	if table.Options.AnteType != BigBlindAnte {
+		t.Errorf("ApplyLevel() ante type = %v, want Big Blind Ante", table.Options.AnteType)
+	}
+}
+
+func TestColorUp(t *testing.T) {
+	// Removing 25 chips, coloring up to 100s
+	stacks := []int{1000, 1025, 1050, 1075, 25, 0}
+	result, err := ColorUp(stacks, 25, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("ColorUp() error = %v", err)
+	}
+
+	for i, r := range result {
+		if r%100 != 0 {
+			t.Errorf("Stack %d = %d, should be a multiple of 100", i, r)
+		}
+	}
+
+	// Stacks without odd chips are unchanged
+	if result[0] != 1000 || result[5] != 0 {
+		t.Errorf("Result = %v, stacks 0 and 5 should be unchanged", result)
+	}
+
+	// Each player racing wins at most one chip
+	for i, r := range result[1:4] {
+		base := stacks[i+1] - stacks[i+1]%100
+		if r != base && r != base+100 {
+			t.Errorf("Stack %d = %d, want %d or %d", i+1, r, base, base+100)
+		}
+	}
+
+	// The 25 stack can't be raced out
+	if result[4] != 100 {
+		t.Errorf("Short stack = %d, want 100", result[4])
+	}
+
+	// 25 + 50 + 75 + 25 = 175 in odd chips rounds to 2 chips in the race,
+	// plus 1 for the short stack if it lost the race
+	total := 0
+	for _, r := range result {
+		total += r
+	}
+	if total != 4000+200 && total != 4000+300 {
+		t.Errorf("Total chips = %d, want 4200 or 4300", total)
+	}
+
+	// The race is reproducible with the same seed
+	again, _ := ColorUp(stacks, 25, 100, rand.New(rand.NewSource(1)))
+	for i := range result {
+		if result[i] != again[i] {
+			t.Fatalf("ColorUp() with the same seed = %v, want %v", again, result)
+		}
+	}
+
+	if _, err := ColorUp(stacks, 0, 100, nil); err != ErrInvalidDenomination {
+		t.Errorf("ColorUp() of 0 chips error = %v, want ErrInvalidDenomination", err)
+	}
+	if _, err := ColorUp(stacks, 25, 0, nil); err != ErrInvalidDenomination {
+		t.Errorf("ColorUp() to 0 chips error = %v, want ErrInvalidDenomination", err)
+	}
+}
+
+func TestTournamentColorUp(t *testing.T) {
+	tourney, clock := newTestTournament(t)
+	tourney.Rand = rand.New(rand.NewSource(1))
+	table := tourney.NewTable(6)
+	for i, stack := range []int{1000, 1025, 1050, 1075, 25} {
+		table.Sit(i, NewPlayer(fmt.Sprintf("P%d", i)), stack)
+	}
+
+	if err := tourney.ApplyLevel(table); err != nil {
+		t.Fatalf("ApplyLevel() error = %v", err)
+	}
+	if table.Seats[1].Stack != 1025 {
+		t.Errorf("Stack before the color-up = %d, want 1025", table.Seats[1].Stack)
+	}
+
+	// The 25 chips go when the level after the break starts
+	clock.Advance(50 * time.Minute)
+	tourney.Update()
+	if err := tourney.ApplyLevel(table); err != nil {
+		t.Fatalf("ApplyLevel() error = %v", err)
+	}
+	stacks := make([]int, 5)
+	for i := range stacks {
+		stacks[i] = table.Seats[i].Stack
+		if stacks[i]%100 != 0 {
+			t.Errorf("Seat %d stack = %d, want a multiple of 100", i, stacks[i])
+		}
+	}
+
+	// Applying the level again leaves the colored-up stacks alone
+	if err := tourney.ApplyLevel(table); err != nil {
+		t.Fatalf("ApplyLevel() error = %v", err)
+	}
+	for i, stack := range stacks {
+		if table.Seats[i].Stack != stack {
+			t.Errorf("Seat %d stack = %d after applying the level again, want %d", i, table.Seats[i].Stack, stack)
+		}
+	}
+	// A color-up broken after the schedule was checked is reported, and the
+	// table is left alone
+	tourney.Levels[tourney.Level()].ColorUpTo = 0
+	table.SmallBlind = 0
+	if err := tourney.ApplyLevel(table); err != ErrInvalidBlindLevel {
+		t.Errorf("ApplyLevel() with a color-up to 0 chips error = %v, want ErrInvalidBlindLevel", err)
+	}
+	if table.SmallBlind != 0 {
+		t.Error("ApplyLevel() with an invalid color-up should not change the table")
+	}
+}
+
+func TestChipRaceBeats(t *testing.T) {
+	if !chipRaceBeats(NewCard(Ace, Clubs), NewCard(King, Spades)) {
+		t.Error("Higher rank should win the chip race")
+	}
+	if !chipRaceBeats(NewCard(Ace, Spades), NewCard(Ace, Hearts)) {
+		t.Error("Spades should beat hearts at equal rank")
+	}
+}
@@ -1,31 +1,74 @@
 package goker
 
+import (
+	"iter"
+	"slices"
+)
+
 // Combinations generates all n-element combinations from the given items.
+// It returns nil unless 0 < n <= len(items); use CombinationsSeq to stream
+// combinations without holding them all in memory.
 func Combinations[T any](items []T, n int) [][]T {
 	if n <= 0 || n > len(items) {
 		return nil
 	}
+	return slices.Collect(CombinationsSeq(items, n))
+}
 
-	result := make([][]T, 0)
-	combination := make([]T, n)
-	var generate func(start, depth int)
+// CombinationsSeq yields every n-element combination of items in
+// lexicographic order of positions, each in a new slice. There is one empty
+// combination when n is 0 and none when n is negative or above len(items).
+func CombinationsSeq[T any](items []T, n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for combo := range CombinationsBuffer(items, n, nil) {
+			if !yield(slices.Clone(combo)) {
+				return
+			}
+		}
+	}
+}
 
-	generate = func(start, depth int) {
-		if depth == n {
-			combo := make([]T, n)
-			copy(combo, combination)
-			result = append(result, combo)
+// CombinationsBuffer yields the same combinations as CombinationsSeq without
+// allocating for each one: every combination is written into buf (or a
+// buffer allocated once if buf is too small), so a yielded slice is only
+// valid until the next iteration and must be copied to be kept.
+func CombinationsBuffer[T any](items []T, n int, buf []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n < 0 || n > len(items) {
 			return
 		}
+		if cap(buf) < n {
+			buf = make([]T, n)
+		}
+		combo := buf[:n]
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+			combo[i] = items[i]
+		}
+
+		for {
+			if !yield(combo) {
+				return
+			}
 
-		for i := start; i <= len(items)-(n-depth); i++ {
-			combination[depth] = items[i]
-			generate(i+1, depth+1)
+			// Advance the rightmost position that can still move, and reset
+			// the positions after it to follow on from it
+			i := n - 1
+			for i >= 0 && indices[i] == len(items)-n+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			combo[i] = items[indices[i]]
+			for j := i + 1; j < n; j++ {
+				indices[j] = indices[j-1] + 1
+				combo[j] = items[indices[j]]
+			}
 		}
 	}
-
-	generate(0, 0)
-	return result
 }
 
 // CardCombinations generates all n-card combinations from the given cards.
@@ -33,6 +76,21 @@ func CardCombinations(cards []Card, n int) [][]Card {
 	return Combinations(cards, n)
 }
 
+// Binomial returns the number of ways to choose k of n items, or 0 if k is
+// negative or above n. It lets callers check how many combinations there
+// are before enumerating them.
+func Binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
 // BitSequenceToInt converts a slice of bit values (0 or 1) to an integer.
 func BitSequenceToInt(bits []int) int {
 	result := 0
@@ -91,3 +91,107 @@ func TestGetBinaryIndexFromCardRank(t *testing.T) {
 		t.Errorf("Ace index (%d) should be less than Two index (%d)", aceIdx, twoIdx)
 	}
 }
+
+func TestCombinationsSeq(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	var got [][]int
+	for combo := range CombinationsSeq(items, 3) {
+		got = append(got, combo)
+	}
+	want := Combinations(items, 3)
+	if len(got) != len(want) || len(got) != 10 {
+		t.Fatalf("CombinationsSeq(5, 3) yielded %d combinations, want 10", len(got))
+	}
+	for i := range got {
+		for j := range got[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("combination %d = %v, want %v", i, got[i], want[i])
+				break
+			}
+		}
+	}
+
+	// Stopping early
+	count := 0
+	for range CombinationsSeq(items, 2) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("stopped after %d combinations, want 3", count)
+	}
+
+	tests := []struct {
+		n        int
+		expected int
+	}{
+		{0, 1},
+		{-1, 0},
+		{6, 0},
+		{5, 1},
+	}
+	for _, tt := range tests {
+		count := 0
+		for range CombinationsSeq(items, tt.n) {
+			count++
+		}
+		if count != tt.expected {
+			t.Errorf("CombinationsSeq(5, %d) yielded %d combinations, want %d", tt.n, count, tt.expected)
+		}
+	}
+}
+
+func TestCombinationsBuffer(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6}
+	buf := make([]int, 4)
+	want := Combinations(items, 4)
+
+	i := 0
+	for combo := range CombinationsBuffer(items, 4, buf) {
+		if &combo[0] != &buf[0] {
+			t.Fatal("CombinationsBuffer didn't reuse the buffer")
+		}
+		for j := range combo {
+			if combo[j] != want[i][j] {
+				t.Errorf("combination %d = %v, want %v", i, combo, want[i])
+				break
+			}
+		}
+		i++
+	}
+	if i != len(want) {
+		t.Errorf("CombinationsBuffer(6, 4) yielded %d combinations, want %d", i, len(want))
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for range CombinationsBuffer(items, 4, buf) {
+		}
+	})
+	if allocs > 2 {
+		t.Errorf("CombinationsBuffer allocated %.0f times per run, want at most 2", allocs)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	tests := []struct {
+		n, k     int
+		expected int
+	}{
+		{52, 5, 2598960},
+		{48, 5, 1712304},
+		{45, 2, 990},
+		{52, 0, 1},
+		{5, 5, 1},
+		{5, 6, 0},
+		{5, -1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := Binomial(tt.n, tt.k); got != tt.expected {
+			t.Errorf("Binomial(%d, %d) = %d, want %d", tt.n, tt.k, got, tt.expected)
+		}
+	}
+}